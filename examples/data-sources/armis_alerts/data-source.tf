@@ -0,0 +1,11 @@
+data "armis_alerts" "unhandled" {
+  policy_ids = [armis_policy.smb_to_plc.id]
+  severities = ["high", "critical"]
+  statuses   = ["unhandled"]
+  time_frame = "1 Day"
+}
+
+output "smb_to_plc_fired" {
+  description = "Whether the policy raised an alert in the last day"
+  value       = data.armis_alerts.unhandled.total > 0
+}
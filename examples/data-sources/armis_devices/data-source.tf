@@ -0,0 +1,10 @@
+data "armis_devices" "plcs" {
+  asq   = "in:devices type:PLC site:\"Kansas City Plant\""
+  limit = 500
+}
+
+# Keep a list of the addresses of the PLCs up to date
+resource "armis_list_entries" "plc_addresses" {
+  list_id = armis_list.plc_addresses.id
+  entries = compact(data.armis_devices.plcs.devices[*].ip)
+}
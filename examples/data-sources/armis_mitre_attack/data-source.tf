@@ -0,0 +1,41 @@
+data "armis_mitre_attack" "phishing" {
+  tactic    = "Initial Access"
+  technique = "Phishing"
+}
+
+data "armis_mitre_attack" "keylogging" {
+  technique     = "Input Capture"
+  sub_technique = "Keylogging"
+}
+
+resource "armis_policy" "credential_theft" {
+  name        = "Credential Theft"
+  description = "Alerts on phishing and keylogging activity."
+  enabled     = true
+  rule_type   = "ACTIVITY"
+  mitre_attack_labels = concat(
+    data.armis_mitre_attack.phishing.labels,
+    data.armis_mitre_attack.keylogging.labels,
+  )
+
+  actions = [
+    {
+      type = "alert"
+      params = {
+        severity = "high"
+        title    = "Credential Theft"
+        type     = "Security - Threat"
+        consolidation = {
+          amount = 1
+          unit   = "Hours"
+        }
+      }
+    }
+  ]
+
+  rules = {
+    and = [
+      "protocol:SMTP",
+    ]
+  }
+}
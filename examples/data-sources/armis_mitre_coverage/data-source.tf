@@ -0,0 +1,19 @@
+data "armis_mitre_coverage" "enterprise" {
+  matrix = "Enterprise"
+}
+
+output "uncovered_initial_access_techniques" {
+  value = data.armis_mitre_coverage.enterprise.matrices["Enterprise"].tactics["TA0001"].uncovered_techniques
+}
+
+# Report a warning on every plan while no enabled policy covers Phishing.
+check "phishing_is_covered" {
+  data "armis_mitre_coverage" "check" {
+    matrix = "Enterprise"
+  }
+
+  assert {
+    condition     = data.armis_mitre_coverage.check.matrices["Enterprise"].tactics["TA0001"].techniques["T1566"].covered
+    error_message = "No enabled Armis policy covers Phishing (Enterprise.TA0001.T1566)."
+  }
+}
@@ -0,0 +1,13 @@
+data "armis_search" "smb_connections" {
+  asq    = "in:connections protocol:SMB timeFrame:\"1 Days\""
+  fields = ["id", "sourceId", "targetId", "protocol"]
+  limit  = 200
+}
+
+locals {
+  smb_connections = jsondecode(data.armis_search.smb_connections.results_json)
+}
+
+output "smb_connection_count" {
+  value = data.armis_search.smb_connections.total
+}
@@ -0,0 +1,37 @@
+data "armis_vulnerabilities" "tracked" {
+  cve_ids = ["CVE-2021-44228", "CVE-2023-4966"]
+}
+
+# Alert on every tracked CVE that affects a device
+resource "armis_policy" "tracked_cves" {
+  for_each = {
+    for v in data.armis_vulnerabilities.tracked.vulnerabilities : v.id => v
+    if v.affected_device_count > 0
+  }
+
+  name        = "Tracked vulnerability ${each.key}"
+  description = each.value.description
+  enabled     = true
+  rule_type   = "VULNERABILITY"
+
+  actions = [
+    {
+      type = "alert"
+      params = {
+        severity = "high"
+        title    = "Device affected by ${each.key}"
+        type     = "Security - Risk"
+        consolidation = {
+          amount = 1
+          unit   = "Days"
+        }
+      }
+    }
+  ]
+
+  rules = {
+    and = [
+      "cveUid:${each.key}",
+    ]
+  }
+}
@@ -0,0 +1,24 @@
+resource "armis_collector" "edge" {
+  name                = "Edge Collector"
+  deployment_type     = "OVA"
+  persist_credentials = false
+}
+
+ephemeral "armis_collector_credentials" "edge" {
+  collector_id = armis_collector.edge.id
+}
+
+# Write the credentials to a secrets manager without storing them in state.
+resource "aws_secretsmanager_secret" "collector" {
+  name = "armis/edge-collector"
+}
+
+resource "aws_secretsmanager_secret_version" "collector" {
+  secret_id = aws_secretsmanager_secret.collector.id
+  secret_string_wo = jsonencode({
+    user        = ephemeral.armis_collector_credentials.edge.user
+    password    = ephemeral.armis_collector_credentials.edge.password
+    license_key = ephemeral.armis_collector_credentials.edge.license_key
+  })
+  secret_string_wo_version = 1
+}
@@ -12,8 +12,27 @@ terraform {
   }
 }
 
-# Provider values can also be set using the ARMIS_API_KEY and ARMIS_API_URL environment variables.
+# Provider values can also be set using the ARMIS_API_KEY (or ARMIS_API_KEY_FILE) and ARMIS_API_URL environment variables.
 provider "armis" {
   api_key = var.api_key
   api_url = "https://example-lab.armis.com"
+
+  # Instead of api_key, the key can be read from a file or from the JSON
+  # printed by a command ({"Version": 1, "ApiKey": "...", "Expiration": "..."}):
+  #
+  #   api_key_file       = "/run/secrets/armis_api_key"
+  #   credential_process = "/usr/local/bin/armis-credentials --profile lab"
+
+  # Optional: tune how throttled (HTTP 429) and transient (5xx) failures are retried.
+  retry {
+    max_attempts = 6
+    min_backoff  = "2s"
+    max_backoff  = "1m"
+  }
+
+  # Optional: reach the tenant through a TLS-inspecting corporate proxy.
+  transport {
+    ca_bundle_file = "/etc/ssl/certs/corporate-root.pem"
+    proxy_url      = "http://proxy.example.com:3128"
+  }
 }
@@ -0,0 +1,4 @@
+import {
+  to = armis_boundary.example
+  id = "3"
+}
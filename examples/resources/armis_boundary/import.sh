@@ -0,0 +1 @@
+terraform import armis_boundary.example 3
@@ -0,0 +1,15 @@
+# Segment the OT network of a plant
+resource "armis_boundary" "plant_ot" {
+  name           = "Kansas City Plant OT"
+  affected_sites = armis_site.plant.name
+
+  rule_aql = {
+    and = [
+      "ipAddress:10.20.0.0/16",
+    ]
+    or = [
+      "type:PLC",
+      "type:HMI",
+    ]
+  }
+}
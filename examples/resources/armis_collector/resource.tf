@@ -1,4 +1,9 @@
 resource "armis_collector" "test_collector" {
   name            = "Test Collector"
   deployment_type = "OVA"
+
+  timeouts {
+    create = "20m"
+    delete = "20m"
+  }
 }
@@ -0,0 +1,5 @@
+# Classify the controllers of a plant
+resource "armis_device_tags" "plant_controllers" {
+  asq  = "in:devices type:PLC,HMI site:\"Kansas City Plant\""
+  tags = ["OT", "Kansas City Controllers"]
+}
@@ -0,0 +1,4 @@
+import {
+  to = armis_list.example
+  id = "12"
+}
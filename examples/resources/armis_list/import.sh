@@ -0,0 +1 @@
+terraform import armis_list.example 12
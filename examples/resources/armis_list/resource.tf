@@ -0,0 +1,6 @@
+# List of engineering workstations allowed to reach the PLCs
+resource "armis_list" "engineering_workstations" {
+  name        = "Engineering Workstations"
+  description = "Hosts allowed to program PLCs"
+  list_type   = "IP_ADDRESS"
+}
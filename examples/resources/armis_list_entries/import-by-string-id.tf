@@ -0,0 +1,4 @@
+import {
+  to = armis_list_entries.example
+  id = "12"
+}
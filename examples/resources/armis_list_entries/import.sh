@@ -0,0 +1 @@
+terraform import armis_list_entries.example 12
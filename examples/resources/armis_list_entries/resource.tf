@@ -0,0 +1,19 @@
+# Add entries to a list, leaving entries added elsewhere alone
+resource "armis_list_entries" "engineering_workstations" {
+  list_id = armis_list.engineering_workstations.id
+  entries = [
+    "10.20.1.15",
+    "10.20.1.16",
+    "10.20.8.0/24",
+  ]
+}
+
+# Own the complete contents of a list
+resource "armis_list_entries" "blocked_domains" {
+  list_id       = armis_list.blocked_domains.id
+  authoritative = true
+  entries = [
+    "example.net",
+    "*.example.org",
+  ]
+}
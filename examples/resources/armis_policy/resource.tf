@@ -27,3 +27,37 @@ resource "armis_policy" "example_policy" {
     ]
   }
 }
+
+# protocol:BMS AND (protocol:SMB OR (protocol:HTTP AND port:8080))
+resource "armis_policy" "nested_rules" {
+  name      = "BMS Lateral Movement Policy"
+  enabled   = true
+  rule_type = "ACTIVITY"
+
+  actions = [
+    {
+      type = "alert"
+      params = {
+        severity = "medium"
+        title    = "BMS Lateral Movement"
+        type     = "Security - Threat"
+      }
+    }
+  ]
+
+  rules = {
+    and      = ["protocol:BMS"]
+    operator = "and"
+    groups = [
+      {
+        or       = ["protocol:SMB"]
+        operator = "or"
+        groups = [
+          {
+            and = ["protocol:HTTP", "port:8080"]
+          }
+        ]
+      }
+    ]
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = armis_site.example
+  id = "12"
+}
@@ -0,0 +1 @@
+terraform import armis_site.example 12
@@ -0,0 +1,9 @@
+# Onboard a new plant and grant its operators access in the same change
+resource "armis_site" "plant" {
+  name      = "Kansas City Plant"
+  location  = "Kansas City, MO"
+  latitude  = 39.0997
+  longitude = -94.5786
+  tier      = "1"
+  user      = "plant.manager@example.com"
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asqtypes provides a Terraform string type for Armis Standard Query
+// (ASQ) values. Armis may return a query with different spacing, quote style
+// or clause order than it was configured with, so values are compared by the
+// normalized form of their parsed query rather than by their text.
+package asqtypes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = QueryType{}
+	_ basetypes.StringValuableWithSemanticEquals = Query{}
+)
+
+// errUnexpectedValue is returned when Terraform passes a value QueryType
+// cannot convert.
+var errUnexpectedValue = errors.New("unexpected value")
+
+// QueryType is the attribute type of ASQ queries and rules.
+type QueryType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t QueryType) String() string {
+	return "asqtypes.QueryType"
+}
+
+// ValueType returns the Value type.
+func (t QueryType) ValueType(_ context.Context) attr.Value {
+	return Query{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t QueryType) Equal(o attr.Type) bool {
+	other, ok := o.(QueryType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t QueryType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Query{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t QueryType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("%w type of %T", errUnexpectedValue, attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%w: converting StringValue to StringValuable: %v", errUnexpectedValue, diags)
+	}
+
+	return stringValuable, nil
+}
+
+// Query is an ASQ query or rule value.
+type Query struct {
+	basetypes.StringValue
+}
+
+// Type returns a QueryType.
+func (v Query) Type(_ context.Context) attr.Type {
+	return QueryType{}
+}
+
+// Equal returns true if the given value is equivalent.
+func (v Query) Equal(o attr.Value) bool {
+	other, ok := o.(Query)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both queries normalize to the same
+// canonical form, so that differences in spacing, quote style or the order of
+// clauses and values do not show as drift. Queries that do not parse are
+// only equal when their text is.
+func (v Query) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(Query)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	if v.ValueString() == newValue.ValueString() {
+		return true, diags
+	}
+
+	prior, err := verify.NormalizeASQ(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	current, err := verify.NormalizeASQ(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return prior == current, diags
+}
+
+// NewQueryNull creates a Query with a null value.
+func NewQueryNull() Query {
+	return Query{StringValue: basetypes.NewStringNull()}
+}
+
+// NewQueryUnknown creates a Query with an unknown value.
+func NewQueryUnknown() Query {
+	return Query{StringValue: basetypes.NewStringUnknown()}
+}
+
+// NewQueryValue creates a Query with a known value.
+func NewQueryValue(value string) Query {
+	return Query{StringValue: basetypes.NewStringValue(value)}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package asqtypes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/asqtypes"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestQueryStringSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		prior string
+		value string
+		want  bool
+	}{
+		{"identical", "in:devices type:PLC", "in:devices type:PLC", true},
+		{"whitespace", "in:devices  type:PLC", " in:devices type:PLC", true},
+		{"quote style", `site:'Plant A'`, `site:"Plant A"`, true},
+		{"clause order", "in:devices site:A type:PLC", "in:devices type:PLC site:A", true},
+		{"value order", "type:PLC,HMI", "type:HMI,PLC", true},
+		{"keyword case", "type:PLC or type:HMI", "type:HMI OR type:PLC", true},
+		{"different value", "in:devices type:PLC", "in:devices type:HMI", false},
+		{"different operator", "riskLevel:>5", "riskLevel:>=5", false},
+		{"and versus or", "type:PLC site:A", "type:PLC OR site:A", false},
+		{"invalid query", "type:(", "type:( ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := asqtypes.NewQueryValue(tt.prior).StringSemanticEquals(context.Background(), asqtypes.NewQueryValue(tt.value))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("StringSemanticEquals(%q, %q) = %t, want %t", tt.prior, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryStringSemanticEqualsWrongType(t *testing.T) {
+	t.Parallel()
+
+	_, diags := asqtypes.NewQueryValue("type:PLC").StringSemanticEquals(context.Background(), basetypes.NewStringValue("type:PLC"))
+	if !diags.HasError() {
+		t.Error("expected an error for a value of another type")
+	}
+}
+
+func TestQueryTypeValueFromTerraform(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		in   tftypes.Value
+		want asqtypes.Query
+	}{
+		{"known", tftypes.NewValue(tftypes.String, "in:devices"), asqtypes.NewQueryValue("in:devices")},
+		{"null", tftypes.NewValue(tftypes.String, nil), asqtypes.NewQueryNull()},
+		{"unknown", tftypes.NewValue(tftypes.String, tftypes.UnknownValue), asqtypes.NewQueryUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := asqtypes.QueryType{}.ValueFromTerraform(ctx, tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ValueFromTerraform() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache memoizes the Armis list endpoints for the lifetime of a
+// provider instance, which Terraform starts once per operation. Data sources
+// and resources that look up roles, users, sites, tags, policies or reports
+// share a single response instead of each listing the tenant again, and
+// concurrent identical calls are collapsed into one request.
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Lister is the part of the Armis client whose responses are cached.
+type Lister interface {
+	GetRoles(ctx context.Context) ([]armis.RoleSettings, error)
+	GetUsers(ctx context.Context) ([]armis.UserSettings, error)
+	GetSites(ctx context.Context) ([]armis.SiteSettings, error)
+	GetTags(ctx context.Context) ([]string, error)
+	GetAllPolicies(ctx context.Context) ([]armis.SinglePolicy, error)
+	GetReports(ctx context.Context) ([]armis.Report, error)
+}
+
+// Cache holds the list responses of one provider instance. The zero value
+// is not usable; create one with New.
+//
+// Resources call the matching Invalidate method after every change they
+// make, so that data sources read later in the same operation see it.
+type Cache struct {
+	client  Lister
+	enabled bool
+
+	roles    entry[armis.RoleSettings]
+	users    entry[armis.UserSettings]
+	sites    entry[armis.SiteSettings]
+	tags     entry[string]
+	policies entry[armis.SinglePolicy]
+	reports  entry[armis.Report]
+}
+
+// New returns a cache in front of client. When enabled is false every call
+// goes straight to the API.
+func New(client Lister, enabled bool) *Cache {
+	return &Cache{client: client, enabled: enabled}
+}
+
+// Roles returns every role of the tenant.
+func (c *Cache) Roles(ctx context.Context) ([]armis.RoleSettings, error) {
+	return c.roles.get(ctx, c.enabled, "roles", c.client.GetRoles)
+}
+
+// Users returns every user of the tenant.
+func (c *Cache) Users(ctx context.Context) ([]armis.UserSettings, error) {
+	return c.users.get(ctx, c.enabled, "users", c.client.GetUsers)
+}
+
+// Sites returns every site of the tenant.
+func (c *Cache) Sites(ctx context.Context) ([]armis.SiteSettings, error) {
+	return c.sites.get(ctx, c.enabled, "sites", c.client.GetSites)
+}
+
+// Tags returns every device tag of the tenant.
+func (c *Cache) Tags(ctx context.Context) ([]string, error) {
+	return c.tags.get(ctx, c.enabled, "tags", c.client.GetTags)
+}
+
+// Policies returns every policy of the tenant.
+func (c *Cache) Policies(ctx context.Context) ([]armis.SinglePolicy, error) {
+	return c.policies.get(ctx, c.enabled, "policies", c.client.GetAllPolicies)
+}
+
+// Reports returns every report of the tenant.
+func (c *Cache) Reports(ctx context.Context) ([]armis.Report, error) {
+	return c.reports.get(ctx, c.enabled, "reports", c.client.GetReports)
+}
+
+// InvalidateRoles drops the cached roles after a role was changed.
+func (c *Cache) InvalidateRoles() { c.roles.invalidate() }
+
+// InvalidateUsers drops the cached users after a user was changed.
+func (c *Cache) InvalidateUsers() { c.users.invalidate() }
+
+// InvalidateSites drops the cached sites after a site was changed.
+func (c *Cache) InvalidateSites() { c.sites.invalidate() }
+
+// InvalidateTags drops the cached tags after device tags were changed.
+func (c *Cache) InvalidateTags() { c.tags.invalidate() }
+
+// InvalidatePolicies drops the cached policies after a policy was changed.
+func (c *Cache) InvalidatePolicies() { c.policies.invalidate() }
+
+// InvalidateReports drops the cached reports after a report was changed.
+func (c *Cache) InvalidateReports() { c.reports.invalidate() }
+
+// entry is the cached result of one list endpoint. The first caller fetches
+// it; callers arriving while the request is in flight wait for its result.
+type entry[T any] struct {
+	mu   sync.Mutex
+	call *call[T]
+}
+
+type call[T any] struct {
+	done  chan struct{}
+	value []T
+	err   error
+}
+
+func (e *entry[T]) get(ctx context.Context, enabled bool, name string, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	if !enabled {
+		return fetch(ctx)
+	}
+
+	for {
+		e.mu.Lock()
+		c := e.call
+		if c == nil {
+			c = &call[T]{done: make(chan struct{})}
+			e.call = c
+			e.mu.Unlock()
+
+			tflog.Debug(ctx, "Listing Armis "+name)
+			c.value, c.err = fetch(ctx)
+			if c.err != nil {
+				// Failures are not cached so that the next caller tries again.
+				e.mu.Lock()
+				if e.call == c {
+					e.call = nil
+				}
+				e.mu.Unlock()
+			}
+			close(c.done)
+
+			return clone(c.value), c.err
+		}
+		e.mu.Unlock()
+
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// The caller that made the request gave up before it finished; this
+		// caller still has time left, so make the request again.
+		if isContextError(c.err) && ctx.Err() == nil {
+			continue
+		}
+
+		tflog.Debug(ctx, "Using cached Armis "+name)
+		return clone(c.value), c.err
+	}
+}
+
+func (e *entry[T]) invalidate() {
+	e.mu.Lock()
+	e.call = nil
+	e.mu.Unlock()
+}
+
+// clone deep-copies the cached slice so that callers sorting, filtering or
+// modifying the result, including nested values such as policy rules and
+// MITRE ATT&CK labels, do not change what the next caller sees.
+func clone[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+
+	return deepCopy(reflect.ValueOf(values)).Interface().([]T)
+}
+
+// deepCopy returns a copy of v that shares no slices, maps or pointers with
+// it. Unexported struct fields are copied shallowly.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := range v.NumField() {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+)
+
+// fakeLister counts the tag requests it receives. Requests block until
+// release is closed, and fail while err is set.
+type fakeLister struct {
+	calls   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func (f *fakeLister) GetTags(ctx context.Context) ([]string, error) {
+	f.calls.Add(1)
+	if f.release != nil {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []string{"critical", "ot"}, nil
+}
+
+func (f *fakeLister) GetRoles(context.Context) ([]armis.RoleSettings, error) { return nil, nil }
+func (f *fakeLister) GetUsers(context.Context) ([]armis.UserSettings, error) { return nil, nil }
+func (f *fakeLister) GetSites(context.Context) ([]armis.SiteSettings, error) { return nil, nil }
+func (f *fakeLister) GetReports(context.Context) ([]armis.Report, error)     { return nil, nil }
+func (f *fakeLister) GetAllPolicies(context.Context) ([]armis.SinglePolicy, error) {
+	return []armis.SinglePolicy{{
+		ID:                "1",
+		Labels:            []string{"Security"},
+		MitreAttackLabels: []armis.MitreAttackLabel{{Matrix: "Enterprise", Tactic: "TA0009", Technique: "T1056"}},
+		Rules: armis.Rules{And: []any{
+			"in:devices",
+			map[string]any{"or": []any{"type:Camera"}},
+		}},
+	}}, nil
+}
+
+func TestCache_ReusesResponses(t *testing.T) {
+	t.Parallel()
+
+	lister := &fakeLister{}
+	cache := New(lister, true)
+
+	first, err := cache.Tags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first[0] = "changed by the caller"
+
+	second, err := cache.Tags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second[0] != "critical" {
+		t.Errorf("expected callers to get their own copy, got %q", second)
+	}
+	if got := lister.calls.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+
+	cache.InvalidateTags()
+	if _, err := cache.Tags(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := lister.calls.Load(); got != 2 {
+		t.Errorf("expected invalidation to cause a new request, got %d requests", got)
+	}
+}
+
+func TestCache_ReturnsDeepCopies(t *testing.T) {
+	t.Parallel()
+
+	cache := New(&fakeLister{}, true)
+
+	first, err := cache.Policies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first[0].Labels[0] = "changed"
+	first[0].MitreAttackLabels[0].Technique = "changed"
+	first[0].Rules.And[0] = "changed"
+	first[0].Rules.And[1].(map[string]any)["or"].([]any)[0] = "changed"
+
+	second, err := cache.Policies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := second[0].Labels[0]; got != "Security" {
+		t.Errorf("expected labels to be copied, got %q", got)
+	}
+	if got := second[0].MitreAttackLabels[0].Technique; got != "T1056" {
+		t.Errorf("expected MITRE ATT&CK labels to be copied, got %q", got)
+	}
+	if got := second[0].Rules.And[0]; got != "in:devices" {
+		t.Errorf("expected rules to be copied, got %v", got)
+	}
+	if got := second[0].Rules.And[1].(map[string]any)["or"].([]any)[0]; got != "type:Camera" {
+		t.Errorf("expected nested rule groups to be copied, got %v", got)
+	}
+}
+
+func TestCache_DeduplicatesConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	lister := &fakeLister{release: make(chan struct{})}
+	cache := New(lister, true)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cache.Tags(context.Background())
+			errs <- err
+		}()
+	}
+
+	// Give every caller the chance to join the request in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(lister.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := lister.calls.Load(); got != 1 {
+		t.Errorf("expected concurrent callers to share 1 request, got %d", got)
+	}
+}
+
+func TestCache_DoesNotCacheFailures(t *testing.T) {
+	t.Parallel()
+
+	lister := &fakeLister{err: errors.New("unavailable")} //nolint:err113 // test fixture
+	cache := New(lister, true)
+
+	if _, err := cache.Tags(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	lister.err = nil
+	tags, err := cache.Tags(context.Background())
+	if err != nil {
+		t.Fatalf("expected the failure to be retried, got %v", err)
+	}
+	if len(tags) != 2 {
+		t.Errorf("expected 2 tags, got %q", tags)
+	}
+}
+
+func TestCache_WaiterRetriesAfterCanceledRequest(t *testing.T) {
+	t.Parallel()
+
+	lister := &fakeLister{release: make(chan struct{})}
+	cache := New(lister, true)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error, 1)
+	go func() {
+		_, err := cache.Tags(leaderCtx)
+		leaderDone <- err
+	}()
+
+	// Wait for the first request to be in flight before joining it.
+	for lister.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiterDone := make(chan error, 1)
+	go func() {
+		_, err := cache.Tags(context.Background())
+		waiterDone <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-leaderDone; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the canceled caller to fail, got %v", err)
+	}
+
+	close(lister.release)
+	if err := <-waiterDone; err != nil {
+		t.Errorf("expected the waiting caller to make its own request, got %v", err)
+	}
+}
+
+func TestCache_Disabled(t *testing.T) {
+	t.Parallel()
+
+	lister := &fakeLister{}
+	cache := New(lister, false)
+
+	for range 3 {
+		if _, err := cache.Tags(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := lister.calls.Load(); got != 3 {
+		t.Errorf("expected every call to reach the API, got %d requests", got)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// expiryWindow is how long before its expiration a cached credential stops
+// being handed out, so that a key is not used right as it expires.
+const expiryWindow = 5 * time.Minute
+
+// Cache keeps credentials returned by credential processes until shortly
+// before they expire. Entries live in memory and, when a directory is set, in
+// one file per command so that later Terraform runs can reuse them.
+// Credentials without an expiration are never cached.
+type Cache struct {
+	dir string
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]Credential
+}
+
+// cacheEntry is the on-disk form of a cached credential.
+type cacheEntry struct {
+	APIKey     string    `json:"ApiKey"`
+	Expiration time.Time `json:"Expiration"`
+}
+
+// NewCache returns a cache that persists entries in dir. An empty dir keeps
+// entries in memory only.
+func NewCache(dir string) *Cache {
+	return &Cache{
+		dir:     dir,
+		now:     time.Now,
+		entries: map[string]Credential{},
+	}
+}
+
+// Get returns the cached credential for key if it is still valid.
+func (c *Cache) Get(key string) (Credential, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cred, ok := c.entries[key]; ok {
+		if c.valid(cred) {
+			return cred, true
+		}
+		delete(c.entries, key)
+	}
+
+	if c.dir == "" {
+		return Credential{}, false
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return Credential{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Credential{}, false
+	}
+
+	cred := Credential{APIKey: entry.APIKey, Expiration: entry.Expiration}
+	if entry.APIKey == "" || !c.valid(cred) {
+		_ = os.Remove(c.path(key))
+		return Credential{}, false
+	}
+
+	c.entries[key] = cred
+
+	return cred, true
+}
+
+// Put stores cred under key. Credentials without an expiration are ignored.
+// Failing to persist an entry is not an error; the credential process simply
+// runs again next time.
+func (c *Cache) Put(key string, cred Credential) {
+	if cred.Expiration.IsZero() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cred
+
+	if c.dir == "" {
+		return
+	}
+
+	data, err := json.Marshal(cacheEntry{APIKey: cred.APIKey, Expiration: cred.Expiration})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a
+	// partial entry.
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// Delete drops the entry stored under key, in memory and on disk.
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	if c.dir != "" {
+		_ = os.Remove(c.path(key))
+	}
+}
+
+func (c *Cache) valid(cred Credential) bool {
+	return !cred.Expiration.IsZero() && c.now().Add(expiryWindow).Before(cred.Expiration)
+}
+
+// path returns the cache file for key. Keys are hashed so that commands,
+// which may contain paths and arguments, map to safe file names.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
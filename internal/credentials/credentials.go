@@ -0,0 +1,179 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package credentials resolves the Armis API key from the sources supported
+// by the provider: the api_key and api_key_file arguments, a credential
+// process and the ARMIS_API_KEY and ARMIS_API_KEY_FILE environment variables.
+//
+// Sources are consulted in the following order and the first one that is set
+// wins:
+//
+//  1. api_key
+//  2. api_key_file
+//  3. credential_process
+//  4. ARMIS_API_KEY
+//  5. ARMIS_API_KEY_FILE
+//
+// The resolved Credential records which source supplied the key so that
+// diagnostics can name it without repeating the secret.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvAPIKey is the environment variable holding the API key.
+	EnvAPIKey = "ARMIS_API_KEY"
+	// EnvAPIKeyFile is the environment variable holding the path of a file
+	// that contains the API key.
+	EnvAPIKeyFile = "ARMIS_API_KEY_FILE"
+
+	// DefaultProcessTimeout bounds how long a credential process may run.
+	DefaultProcessTimeout = time.Minute
+)
+
+var (
+	// ErrNoCredentials is returned when none of the sources is set.
+	ErrNoCredentials = errors.New("no Armis API key source is configured")
+	// ErrEmptyAPIKey is returned when a source is set but yields an empty key.
+	ErrEmptyAPIKey = errors.New("the API key is empty")
+)
+
+// Source identifies where an API key came from.
+type Source struct {
+	// Name describes the kind of source, for example "api_key_file provider argument".
+	Name string
+	// Location is the file path or command behind the source, if any. It
+	// never contains the key itself.
+	Location string
+}
+
+// String renders the source for diagnostics and logs.
+func (s Source) String() string {
+	if s.Location == "" {
+		return s.Name
+	}
+
+	return fmt.Sprintf("%s (%s)", s.Name, s.Location)
+}
+
+// Credential is a resolved API key together with its origin.
+type Credential struct {
+	APIKey string
+	Source Source
+	// Expiration is when a credential returned by a credential process stops
+	// being valid. It is zero for keys that do not expire.
+	Expiration time.Time
+}
+
+// Config holds the credential related provider arguments.
+type Config struct {
+	APIKey            string
+	APIKeyFile        string
+	CredentialProcess string
+}
+
+// Resolver resolves API keys from the configured sources.
+type Resolver struct {
+	// Getenv looks up environment variables. Defaults to os.Getenv.
+	Getenv func(string) string
+	// Cache keeps the output of credential processes until it expires. A nil
+	// cache runs the process on every resolution.
+	Cache *Cache
+	// ProcessTimeout bounds how long a credential process may run.
+	ProcessTimeout time.Duration
+}
+
+var (
+	sharedCachesMu sync.Mutex
+	sharedCaches   = map[string]*Cache{}
+)
+
+// NewResolver returns a resolver that reads the process environment and
+// shares a credential process cache with every other resolver in the
+// provider process that uses the same cacheDir. Cached credentials are kept
+// in memory only unless cacheDir is set, in which case they are also
+// persisted there for later Terraform runs.
+func NewResolver(cacheDir string) *Resolver {
+	sharedCachesMu.Lock()
+	defer sharedCachesMu.Unlock()
+
+	cache, ok := sharedCaches[cacheDir]
+	if !ok {
+		cache = NewCache(cacheDir)
+		sharedCaches[cacheDir] = cache
+	}
+
+	return &Resolver{
+		Getenv:         os.Getenv,
+		Cache:          cache,
+		ProcessTimeout: DefaultProcessTimeout,
+	}
+}
+
+// Resolve returns the API key from the highest precedence source that is set.
+// Errors name the source involved but never include the key.
+func (r *Resolver) Resolve(ctx context.Context, config Config) (Credential, error) {
+	getenv := r.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+
+	switch {
+	case config.APIKey != "":
+		return Credential{
+			APIKey: config.APIKey,
+			Source: Source{Name: "api_key provider argument"},
+		}, nil
+	case config.APIKeyFile != "":
+		return readKeyFile(Source{Name: "api_key_file provider argument", Location: config.APIKeyFile})
+	case config.CredentialProcess != "":
+		return r.runProcess(ctx, config.CredentialProcess)
+	}
+
+	if key := getenv(EnvAPIKey); key != "" {
+		return Credential{
+			APIKey: key,
+			Source: Source{Name: EnvAPIKey + " environment variable"},
+		}, nil
+	}
+	if file := getenv(EnvAPIKeyFile); file != "" {
+		return readKeyFile(Source{Name: EnvAPIKeyFile + " environment variable", Location: file})
+	}
+
+	return Credential{}, ErrNoCredentials
+}
+
+// Forget drops the cached credential process output for config, so that the
+// process runs again the next time the key is resolved. It is used once the
+// Armis API has rejected the key.
+func (r *Resolver) Forget(config Config) {
+	if r.Cache == nil || config.APIKey != "" || config.APIKeyFile != "" || config.CredentialProcess == "" {
+		return
+	}
+
+	r.Cache.Delete(config.CredentialProcess)
+}
+
+// readKeyFile reads an API key from the file named by the source. Leading and
+// trailing whitespace, including the final newline, is ignored.
+func readKeyFile(source Source) (Credential, error) {
+	data, err := os.ReadFile(source.Location)
+	if err != nil {
+		return Credential{}, fmt.Errorf("reading API key from %s: %w", source, err)
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return Credential{}, fmt.Errorf("reading API key from %s: %w", source, ErrEmptyAPIKey)
+	}
+
+	return Credential{APIKey: key, Source: source}, nil
+}
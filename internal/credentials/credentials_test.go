@@ -0,0 +1,400 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+
+	return path
+}
+
+// writeScript writes an executable shell script that prints output and
+// appends a line to a counter file on every run.
+func writeScript(t *testing.T, output string) (command, counter string) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use a POSIX shell script")
+	}
+
+	dir := t.TempDir()
+	counter = filepath.Join(dir, "calls")
+	script := filepath.Join(dir, "credential process.sh")
+	content := "#!/bin/sh\necho run >> '" + counter + "'\ncat <<'EOF'\n" + output + "\nEOF\n"
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil { //nolint:gosec // The test script must be executable.
+		t.Fatalf("writing script: %v", err)
+	}
+
+	return `"` + script + `"`, counter
+}
+
+func countCalls(t *testing.T, counter string) int {
+	t.Helper()
+
+	data, err := os.ReadFile(counter)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0
+	}
+	if err != nil {
+		t.Fatalf("reading counter: %v", err)
+	}
+
+	return strings.Count(string(data), "run")
+}
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeFile(t, "config-key", "  key-from-config-file\n")
+	envFile := writeFile(t, "env-key", "key-from-env-file\n")
+
+	tests := []struct {
+		name           string
+		config         Config
+		env            map[string]string
+		expectedKey    string
+		expectedSource string
+	}{
+		{
+			name:           "api_key wins over everything",
+			config:         Config{APIKey: "key-from-config", APIKeyFile: configFile},
+			env:            map[string]string{EnvAPIKey: "key-from-env"},
+			expectedKey:    "key-from-config",
+			expectedSource: "api_key provider argument",
+		},
+		{
+			name:           "api_key_file wins over the environment",
+			config:         Config{APIKeyFile: configFile},
+			env:            map[string]string{EnvAPIKey: "key-from-env", EnvAPIKeyFile: envFile},
+			expectedKey:    "key-from-config-file",
+			expectedSource: "api_key_file provider argument (" + configFile + ")",
+		},
+		{
+			name:           "ARMIS_API_KEY wins over ARMIS_API_KEY_FILE",
+			env:            map[string]string{EnvAPIKey: "key-from-env", EnvAPIKeyFile: envFile},
+			expectedKey:    "key-from-env",
+			expectedSource: "ARMIS_API_KEY environment variable",
+		},
+		{
+			name:           "ARMIS_API_KEY_FILE is the last resort",
+			env:            map[string]string{EnvAPIKeyFile: envFile},
+			expectedKey:    "key-from-env-file",
+			expectedSource: "ARMIS_API_KEY_FILE environment variable (" + envFile + ")",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolver := &Resolver{Getenv: env(tt.env)}
+			cred, err := resolver.Resolve(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cred.APIKey != tt.expectedKey {
+				t.Errorf("expected key %q, got %q", tt.expectedKey, cred.APIKey)
+			}
+			if got := cred.Source.String(); got != tt.expectedSource {
+				t.Errorf("expected source %q, got %q", tt.expectedSource, got)
+			}
+		})
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	t.Parallel()
+
+	emptyFile := writeFile(t, "empty", "\n\n")
+	missingFile := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name        string
+		config      Config
+		env         map[string]string
+		expectedErr error
+		contains    string
+	}{
+		{
+			name:        "no source",
+			expectedErr: ErrNoCredentials,
+		},
+		{
+			name:        "empty key file",
+			config:      Config{APIKeyFile: emptyFile},
+			expectedErr: ErrEmptyAPIKey,
+			contains:    "api_key_file provider argument (" + emptyFile + ")",
+		},
+		{
+			name:        "missing key file from the environment",
+			env:         map[string]string{EnvAPIKeyFile: missingFile},
+			expectedErr: os.ErrNotExist,
+			contains:    "ARMIS_API_KEY_FILE environment variable",
+		},
+		{
+			name:        "unterminated quote in credential process",
+			config:      Config{CredentialProcess: `"/usr/bin/armis-creds`},
+			expectedErr: ErrInvalidCommand,
+			contains:    "credential_process provider argument",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolver := &Resolver{Getenv: env(tt.env)}
+			_, err := resolver.Resolve(context.Background(), tt.config)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+			if tt.contains != "" && !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("expected error to contain %q, got %q", tt.contains, err.Error())
+			}
+		})
+	}
+}
+
+func TestResolve_CredentialProcess(t *testing.T) {
+	t.Parallel()
+
+	expiration := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	command, counter := writeScript(t, `{"Version": 1, "ApiKey": "key-from-process", "Expiration": "`+expiration.Format(time.RFC3339)+`"}`)
+
+	resolver := &Resolver{Getenv: env(nil), Cache: NewCache(t.TempDir())}
+	config := Config{CredentialProcess: command}
+
+	for i := 0; i < 2; i++ {
+		cred, err := resolver.Resolve(context.Background(), config)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cred.APIKey != "key-from-process" {
+			t.Errorf("expected key from process, got %q", cred.APIKey)
+		}
+		if !cred.Expiration.Equal(expiration) {
+			t.Errorf("expected expiration %s, got %s", expiration, cred.Expiration)
+		}
+		if !strings.HasPrefix(cred.Source.String(), "credential_process provider argument") {
+			t.Errorf("unexpected source %q", cred.Source)
+		}
+	}
+
+	if got := countCalls(t, counter); got != 1 {
+		t.Errorf("expected the process to run once thanks to the cache, got %d runs", got)
+	}
+}
+
+func TestResolve_CredentialProcessWithoutExpirationIsNotCached(t *testing.T) {
+	t.Parallel()
+
+	command, counter := writeScript(t, `{"Version": 1, "ApiKey": "key-from-process"}`)
+
+	resolver := &Resolver{Getenv: env(nil), Cache: NewCache(t.TempDir())}
+	for i := 0; i < 2; i++ {
+		if _, err := resolver.Resolve(context.Background(), Config{CredentialProcess: command}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := countCalls(t, counter); got != 2 {
+		t.Errorf("expected the process to run twice, got %d runs", got)
+	}
+}
+
+func TestResolve_ForgetRunsTheProcessAgain(t *testing.T) {
+	t.Parallel()
+
+	expiration := time.Now().Add(time.Hour).UTC()
+	command, counter := writeScript(t, `{"Version": 1, "ApiKey": "key-from-process", "Expiration": "`+expiration.Format(time.RFC3339)+`"}`)
+
+	dir := t.TempDir()
+	resolver := &Resolver{Getenv: env(nil), Cache: NewCache(dir)}
+	config := Config{CredentialProcess: command}
+
+	if _, err := resolver.Resolve(context.Background(), config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Keys from other sources have nothing cached to forget.
+	resolver.Forget(Config{APIKey: "key", CredentialProcess: command})
+	if _, err := resolver.Resolve(context.Background(), config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countCalls(t, counter); got != 1 {
+		t.Fatalf("expected the cached key to be used, got %d runs", got)
+	}
+
+	resolver.Forget(config)
+	if _, err := os.Stat(resolver.Cache.path(command)); !os.IsNotExist(err) {
+		t.Errorf("expected the cache file to be removed, got %v", err)
+	}
+	if _, err := resolver.Resolve(context.Background(), config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countCalls(t, counter); got != 2 {
+		t.Errorf("expected the process to run again after Forget, got %d runs", got)
+	}
+}
+
+func TestNewResolver_KeepsCacheInMemory(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewResolver("")
+	if resolver.Cache == nil || resolver.Cache.dir != "" {
+		t.Fatalf("expected an in-memory cache, got %+v", resolver.Cache)
+	}
+	if NewResolver("").Cache != resolver.Cache {
+		t.Error("expected resolvers to share the cache")
+	}
+}
+
+func TestResolve_CredentialProcessFailure(t *testing.T) {
+	t.Parallel()
+
+	command, _ := writeScript(t, `{"Version": 1, "ApiKey": "s3cr3t-value"`)
+
+	resolver := &Resolver{Getenv: env(nil)}
+	_, err := resolver.Resolve(context.Background(), Config{CredentialProcess: command})
+	if !errors.Is(err, ErrInvalidProcessOutput) {
+		t.Fatalf("expected ErrInvalidProcessOutput, got %v", err)
+	}
+	if strings.Contains(err.Error(), "s3cr3t-value") {
+		t.Errorf("error leaks the key: %q", err.Error())
+	}
+}
+
+func TestParseProcessOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		output      string
+		expectedKey string
+		expectError bool
+	}{
+		{"valid", `{"Version": 1, "ApiKey": " key "}`, "key", false},
+		{"wrong version", `{"Version": 2, "ApiKey": "key"}`, "", true},
+		{"missing key", `{"Version": 1}`, "", true},
+		{"not json", `key`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cred, err := parseProcessOutput([]byte(tt.output))
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cred.APIKey != tt.expectedKey {
+				t.Errorf("expected key %q, got %q", tt.expectedKey, cred.APIKey)
+			}
+		})
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		command     string
+		expected    []string
+		expectError bool
+	}{
+		{`armis-creds`, []string{"armis-creds"}, false},
+		{`  vault read  -field=key secret/armis `, []string{"vault", "read", "-field=key", "secret/armis"}, false},
+		{`"/opt/my tools/creds" --profile 'prod lab'`, []string{"/opt/my tools/creds", "--profile", "prod lab"}, false},
+		{`creds --name a\ b`, []string{"creds", "--name", "a b"}, false},
+		{`creds ""`, []string{"creds", ""}, false},
+		{`creds 'unterminated`, nil, true},
+		{`creds \`, nil, true},
+		{`   `, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := splitCommand(tt.command)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") || len(got) != len(tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cache := NewCache(dir)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("no-expiry", Credential{APIKey: "key"})
+	if _, ok := cache.Get("no-expiry"); ok {
+		t.Error("credentials without an expiration must not be cached")
+	}
+
+	cache.Put("cmd", Credential{APIKey: "key", Expiration: now.Add(time.Hour)})
+
+	// A fresh cache over the same directory reads the persisted entry.
+	reloaded := NewCache(dir)
+	reloaded.now = cache.now
+	cred, ok := reloaded.Get("cmd")
+	if !ok || cred.APIKey != "key" {
+		t.Fatalf("expected persisted entry, got %+v, %t", cred, ok)
+	}
+
+	info, err := os.Stat(reloaded.path("cmd"))
+	if err != nil {
+		t.Fatalf("stat cache file: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("expected cache file mode 0600, got %o", info.Mode().Perm())
+	}
+
+	// Entries close to their expiration are no longer handed out.
+	now = now.Add(time.Hour - expiryWindow)
+	if _, ok := reloaded.Get("cmd"); ok {
+		t.Error("expected entry within the expiry window to be ignored")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// processOutputVersion is the only output format understood from credential
+// processes.
+const processOutputVersion = 1
+
+// maxStderrBytes limits how much of a failing process's stderr is quoted in
+// the returned error.
+const maxStderrBytes = 1024
+
+var (
+	// ErrInvalidProcessOutput is returned when a credential process prints
+	// something other than the expected JSON document.
+	ErrInvalidProcessOutput = errors.New("invalid credential process output")
+	// ErrInvalidCommand is returned when the credential_process value cannot
+	// be split into a program and its arguments.
+	ErrInvalidCommand = errors.New("invalid credential process command")
+)
+
+// processOutput is the JSON document a credential process prints on stdout:
+//
+//	{"Version": 1, "ApiKey": "...", "Expiration": "2025-01-01T00:00:00Z"}
+//
+// Expiration is optional; credentials without one are not cached.
+type processOutput struct {
+	Version    int        `json:"Version"`
+	APIKey     string     `json:"ApiKey"`
+	Expiration *time.Time `json:"Expiration,omitempty"`
+}
+
+// runProcess returns the credential printed by the given command, using the
+// cache when it holds an unexpired entry for the same command.
+func (r *Resolver) runProcess(ctx context.Context, command string) (Credential, error) {
+	source := Source{Name: "credential_process provider argument", Location: command}
+
+	if r.Cache != nil {
+		if cred, ok := r.Cache.Get(command); ok {
+			cred.Source = source
+			return cred, nil
+		}
+	}
+
+	args, err := splitCommand(command)
+	if err != nil {
+		return Credential{}, fmt.Errorf("running %s: %w", source, err)
+	}
+
+	timeout := r.ProcessTimeout
+	if timeout <= 0 {
+		timeout = DefaultProcessTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // The command is supplied by the operator on purpose.
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > maxStderrBytes {
+			msg = msg[:maxStderrBytes] + "..."
+		}
+		if msg != "" {
+			return Credential{}, fmt.Errorf("running %s: %w: %s", source, err, msg)
+		}
+		return Credential{}, fmt.Errorf("running %s: %w", source, err)
+	}
+
+	cred, err := parseProcessOutput(stdout.Bytes())
+	if err != nil {
+		return Credential{}, fmt.Errorf("running %s: %w", source, err)
+	}
+	cred.Source = source
+
+	if r.Cache != nil {
+		r.Cache.Put(command, cred)
+	}
+
+	return cred, nil
+}
+
+// parseProcessOutput decodes and validates the output of a credential
+// process. Error messages never include the key.
+func parseProcessOutput(data []byte) (Credential, error) {
+	var out processOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return Credential{}, fmt.Errorf("%w: stdout is not a JSON object", ErrInvalidProcessOutput)
+	}
+
+	if out.Version != processOutputVersion {
+		return Credential{}, fmt.Errorf("%w: unsupported Version %d, expected %d", ErrInvalidProcessOutput, out.Version, processOutputVersion)
+	}
+	if strings.TrimSpace(out.APIKey) == "" {
+		return Credential{}, fmt.Errorf("%w: %w", ErrInvalidProcessOutput, ErrEmptyAPIKey)
+	}
+
+	cred := Credential{APIKey: strings.TrimSpace(out.APIKey)}
+	if out.Expiration != nil {
+		cred.Expiration = *out.Expiration
+	}
+
+	return cred, nil
+}
+
+// splitCommand splits a command line into arguments. Arguments are separated
+// by whitespace; single and double quotes group words, and a backslash
+// escapes the next character outside single quotes.
+func splitCommand(command string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, c := range command {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("%w: unterminated %c quote", ErrInvalidCommand, quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("%w: trailing backslash", ErrInvalidCommand)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty command", ErrInvalidCommand)
+	}
+
+	return args, nil
+}
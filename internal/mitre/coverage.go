@@ -0,0 +1,35 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package mitre
+
+import "slices"
+
+// Coverage records which policies cover each technique under each of its
+// tactics. A label with a sub-technique covers its technique.
+type Coverage struct {
+	policies map[Label][]string
+}
+
+// NewCoverage returns an empty Coverage.
+func NewCoverage() *Coverage {
+	return &Coverage{policies: make(map[Label][]string)}
+}
+
+// Add records that the policy with the given ID covers label.
+func (c *Coverage) Add(label Label, policyID string) {
+	label.SubTechnique = ""
+	if !slices.Contains(c.policies[label], policyID) {
+		c.policies[label] = append(c.policies[label], policyID)
+	}
+}
+
+// PolicyIDs returns the sorted IDs of the policies covering the technique of
+// label under its tactic. The sub-technique of label is ignored.
+func (c *Coverage) PolicyIDs(label Label) []string {
+	label.SubTechnique = ""
+	ids := slices.Clone(c.policies[label])
+	slices.Sort(ids)
+
+	return ids
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package mitre_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
+)
+
+func TestCoverage(t *testing.T) {
+	t.Parallel()
+
+	keylogging := mitre.Label{Matrix: "Enterprise", Tactic: "TA0009", Technique: "T1056", SubTechnique: "001"}
+	apiHooking := mitre.Label{Matrix: "Enterprise", Tactic: "TA0009", Technique: "T1056", SubTechnique: "004"}
+	credentialAccess := mitre.Label{Matrix: "Enterprise", Tactic: "TA0006", Technique: "T1056"}
+
+	coverage := mitre.NewCoverage()
+	coverage.Add(keylogging, "2")
+	coverage.Add(apiHooking, "1")
+	coverage.Add(keylogging, "2")
+	coverage.Add(credentialAccess, "3")
+
+	tests := []struct {
+		name  string
+		label mitre.Label
+		want  []string
+	}{
+		{"technique", mitre.Label{Matrix: "Enterprise", Tactic: "TA0009", Technique: "T1056"}, []string{"1", "2"}},
+		{"sub-technique ignored", keylogging, []string{"1", "2"}},
+		{"same technique under another tactic", credentialAccess, []string{"3"}},
+		{"uncovered technique", mitre.Label{Matrix: "Enterprise", Tactic: "TA0001", Technique: "T1566"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := coverage.PolicyIDs(tt.label); !slices.Equal(got, tt.want) {
+				t.Errorf("PolicyIDs(%s) = %v, want %v", tt.label, got, tt.want)
+			}
+		})
+	}
+}
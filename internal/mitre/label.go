@@ -0,0 +1,239 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package mitre
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxSuggestions is the number of alternatives offered when a label is not in
+// the catalog.
+const maxSuggestions = 3
+
+// labelPattern matches labels such as Enterprise.TA0009.T1056.001.
+var labelPattern = regexp.MustCompile(`^(Enterprise|Mobile|ICS)\.(TA\d{4})\.(T\d{4})(?:\.(\d{3}))?$`)
+
+// ErrLabelFormat is returned when a label does not have the
+// Matrix.TA####.T####[.###] format.
+var ErrLabelFormat = errors.New("must be a valid MITRE ATT&CK label (e.g., Enterprise.TA0009.T1056.001)")
+
+// Label identifies a tactic and technique, and optionally a sub-technique,
+// of a matrix by their IDs.
+type Label struct {
+	Matrix       string
+	Tactic       string
+	Technique    string
+	SubTechnique string
+}
+
+// String returns the label in the Matrix.TA####.T####[.###] format, such as
+// Enterprise.TA0009.T1056.001.
+func (l Label) String() string {
+	label := l.Matrix + "." + l.Tactic + "." + l.Technique
+	if l.SubTechnique != "" {
+		label += "." + l.SubTechnique
+	}
+
+	return label
+}
+
+// ParseLabel splits a label in the Matrix.TA####.T####[.###] format into its
+// IDs without looking them up. It returns ErrLabelFormat for any other
+// string.
+func ParseLabel(s string) (Label, error) {
+	match := labelPattern.FindStringSubmatch(s)
+	if match == nil {
+		return Label{}, ErrLabelFormat
+	}
+
+	return Label{Matrix: match[1], Tactic: match[2], Technique: match[3], SubTechnique: match[4]}, nil
+}
+
+// CheckLabel reports whether the tactic of label exists in its matrix, the
+// technique belongs to the tactic and the sub-technique, when set, belongs to
+// the technique. Errors wrap ErrUnknown or ErrMismatch and suggest the
+// closest labels that are in the catalog.
+func (c *Catalog) CheckLabel(label Label) error {
+	matrix := c.Matrix(label.Matrix)
+	if matrix == nil {
+		return fmt.Errorf("matrix %s is %w%s", label.Matrix, ErrUnknown, didYouMean(c.matrixNames()))
+	}
+
+	tactic := matrix.Tactic(label.Tactic)
+	technique := matrix.Technique(label.Technique)
+
+	switch {
+	case tactic == nil && technique != nil:
+		return fmt.Errorf("%s tactic %s is %w%s%s", matrix.Name, label.Tactic, ErrUnknown,
+			c.otherMatrix(matrix, label.Tactic, "tactic"), didYouMean(techniqueLabels(matrix, technique, label.SubTechnique)))
+	case tactic == nil:
+		return fmt.Errorf("%s tactic %s is %w%s%s", matrix.Name, label.Tactic, ErrUnknown,
+			c.otherMatrix(matrix, label.Tactic, "tactic"), didYouMean(closestTactics(matrix, label.Tactic)))
+	case technique == nil:
+		return fmt.Errorf("%s technique %s is %w%s%s", matrix.Name, label.Technique, ErrUnknown,
+			c.otherMatrix(matrix, label.Technique, "technique"), didYouMean(closestTechniques(matrix, tactic, label.Technique)))
+	case !technique.HasTactic(tactic.ID):
+		return fmt.Errorf("technique %s (%s) %w tactic %s (%s)%s", technique.ID, technique.Name, ErrMismatch,
+			tactic.ID, tactic.Name, didYouMean(techniqueLabels(matrix, technique, label.SubTechnique)))
+	}
+
+	if label.SubTechnique == "" || technique.SubTechnique(label.SubTechnique) != nil {
+		return nil
+	}
+
+	base := Label{Matrix: matrix.Name, Tactic: tactic.ID, Technique: technique.ID}
+	if len(technique.SubTechniques) == 0 {
+		return fmt.Errorf("sub-technique %s.%s is %w, technique %s (%s) has no sub-techniques%s",
+			technique.ID, label.SubTechnique, ErrUnknown, technique.ID, technique.Name, didYouMean([]string{base.String()}))
+	}
+
+	return fmt.Errorf("sub-technique %s.%s is %w%s", technique.ID, label.SubTechnique, ErrUnknown,
+		didYouMean(closestSubTechniques(base, technique, label.SubTechnique)))
+}
+
+func (c *Catalog) matrixNames() []string {
+	names := make([]string, 0, len(c.Matrices))
+	for _, matrix := range c.Matrices {
+		names = append(names, matrix.Name)
+	}
+
+	return names
+}
+
+// otherMatrix describes the matrix other than skip that has a tactic or
+// technique with the given ID, or returns an empty string.
+func (c *Catalog) otherMatrix(skip *Matrix, id, kind string) string {
+	for _, matrix := range c.Matrices {
+		if matrix == skip {
+			continue
+		}
+		if (kind == "tactic" && matrix.Tactic(id) != nil) || (kind == "technique" && matrix.Technique(id) != nil) {
+			return fmt.Sprintf(" (%s is a %s of the %s matrix)", id, kind, matrix.Name)
+		}
+	}
+
+	return ""
+}
+
+// techniqueLabels returns the labels of technique under each of its tactics.
+func techniqueLabels(matrix *Matrix, technique *Technique, subTechnique string) []string {
+	labels := make([]string, 0, len(technique.Tactics))
+	for _, tacticID := range technique.Tactics {
+		label := Label{Matrix: matrix.Name, Tactic: tacticID, Technique: technique.ID}
+		if sub := technique.SubTechnique(subTechnique); sub != nil {
+			label.SubTechnique = sub.ID
+		}
+		labels = append(labels, label.String())
+	}
+
+	return labels
+}
+
+func closestTactics(matrix *Matrix, id string) []string {
+	candidates := make([]candidate, 0, len(matrix.Tactics))
+	for _, tactic := range matrix.Tactics {
+		candidates = append(candidates, candidate{keys: []string{tactic.ID}, text: fmt.Sprintf("%s (%s)", tactic.ID, tactic.Name)})
+	}
+
+	return closest(id, candidates)
+}
+
+func closestTechniques(matrix *Matrix, tactic *Tactic, id string) []string {
+	techniques := matrix.TacticTechniques(tactic.ID)
+	candidates := make([]candidate, 0, len(techniques))
+	for _, technique := range techniques {
+		label := Label{Matrix: matrix.Name, Tactic: tactic.ID, Technique: technique.ID}
+		candidates = append(candidates, candidate{keys: []string{technique.ID}, text: fmt.Sprintf("%s (%s)", label, technique.Name)})
+	}
+
+	return closest(id, candidates)
+}
+
+func closestSubTechniques(base Label, technique *Technique, id string) []string {
+	candidates := make([]candidate, 0, len(technique.SubTechniques))
+	for _, sub := range technique.SubTechniques {
+		label := base
+		label.SubTechnique = sub.ID
+		candidates = append(candidates, candidate{keys: []string{sub.ID}, text: fmt.Sprintf("%s (%s)", label, sub.Name)})
+	}
+
+	return closest(id, candidates)
+}
+
+// candidate is a possible suggestion, matched against the input by any of
+// its keys and shown as text.
+type candidate struct {
+	keys []string
+	text string
+}
+
+// closest returns the text of the candidates nearest to input by edit
+// distance ignoring case, at most maxSuggestions of them.
+func closest(input string, candidates []candidate) []string {
+	input = strings.ToLower(strings.TrimSpace(input))
+	distance := func(c candidate) int {
+		best := -1
+		for _, key := range c.keys {
+			if d := editDistance(input, strings.ToLower(key)); best < 0 || d < best {
+				best = d
+			}
+		}
+		return best
+	}
+
+	sorted := slices.Clone(candidates)
+	slices.SortStableFunc(sorted, func(a, b candidate) int {
+		return distance(a) - distance(b)
+	})
+
+	if len(sorted) > maxSuggestions {
+		sorted = sorted[:maxSuggestions]
+	}
+
+	suggestions := make([]string, 0, len(sorted))
+	for _, c := range sorted {
+		suggestions = append(suggestions, c.text)
+	}
+
+	return suggestions
+}
+
+// didYouMean formats suggestions as a clause to append to an error message.
+func didYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return ", did you mean " + suggestions[0] + "?"
+	}
+
+	return ", did you mean " + strings.Join(suggestions[:len(suggestions)-1], ", ") + " or " + suggestions[len(suggestions)-1] + "?"
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mitre provides an embedded catalog of the MITRE ATT&CK Enterprise,
+// Mobile and ICS matrices, used to convert between the tactic and technique
+// names returned by the Armis API and the Matrix.TA####.T####[.###] labels
+// used in configuration.
+package mitre
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// attackJSON is the ATT&CK catalog. Each technique lists the IDs of the
+// tactics it belongs to, and sub-techniques are identified by the three digit
+// suffix of their ID.
+//
+//go:embed attack.json
+var attackJSON []byte
+
+var (
+	// ErrUnknown is returned when a matrix, tactic, technique or
+	// sub-technique is not in the catalog.
+	ErrUnknown = errors.New("not in the MITRE ATT&CK catalog")
+
+	// ErrMismatch is returned when a technique does not belong to a tactic.
+	ErrMismatch = errors.New("does not belong to")
+)
+
+// Catalog is a set of ATT&CK matrices.
+type Catalog struct {
+	// Version is the ATT&CK release the catalog was taken from.
+	Version  string    `json:"version"`
+	Matrices []*Matrix `json:"matrices"`
+}
+
+// Matrix is an ATT&CK matrix such as Enterprise.
+type Matrix struct {
+	Name       string       `json:"name"`
+	Tactics    []*Tactic    `json:"tactics"`
+	Techniques []*Technique `json:"techniques"`
+}
+
+// Tactic is a tactic of a matrix, such as TA0009 Collection.
+type Tactic struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Technique is a technique of a matrix, such as T1056 Input Capture.
+type Technique struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Tactics are the IDs of the tactics the technique belongs to.
+	Tactics       []string        `json:"tactics"`
+	SubTechniques []*SubTechnique `json:"subtechniques"`
+}
+
+// SubTechnique is a sub-technique of a technique, such as 001 Keylogging.
+type SubTechnique struct {
+	// ID is the three digit suffix of the sub-technique ID.
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+var loadCatalog = sync.OnceValue(func() *Catalog {
+	var catalog Catalog
+	if err := json.Unmarshal(attackJSON, &catalog); err != nil {
+		panic(fmt.Sprintf("mitre: invalid embedded catalog: %v", err))
+	}
+
+	return &catalog
+})
+
+// Default returns the embedded ATT&CK catalog.
+func Default() *Catalog {
+	return loadCatalog()
+}
+
+// Matrix returns the matrix of the given name, ignoring case, or nil.
+func (c *Catalog) Matrix(name string) *Matrix {
+	name = strings.TrimSpace(name)
+	for _, matrix := range c.Matrices {
+		if strings.EqualFold(matrix.Name, name) {
+			return matrix
+		}
+	}
+
+	return nil
+}
+
+// Tactic returns the tactic with the given ID or name, ignoring case, or nil.
+func (m *Matrix) Tactic(idOrName string) *Tactic {
+	idOrName = strings.TrimSpace(idOrName)
+	for _, tactic := range m.Tactics {
+		if strings.EqualFold(tactic.ID, idOrName) || strings.EqualFold(tactic.Name, idOrName) {
+			return tactic
+		}
+	}
+
+	return nil
+}
+
+// Technique returns the technique with the given ID or name, ignoring case,
+// or nil.
+func (m *Matrix) Technique(idOrName string) *Technique {
+	idOrName = strings.TrimSpace(idOrName)
+	for _, technique := range m.Techniques {
+		if strings.EqualFold(technique.ID, idOrName) || strings.EqualFold(technique.Name, idOrName) {
+			return technique
+		}
+	}
+
+	return nil
+}
+
+// TacticTechniques returns the techniques that belong to the tactic with the
+// given ID, in catalog order.
+func (m *Matrix) TacticTechniques(tacticID string) []*Technique {
+	var techniques []*Technique
+	for _, technique := range m.Techniques {
+		if technique.HasTactic(tacticID) {
+			techniques = append(techniques, technique)
+		}
+	}
+
+	return techniques
+}
+
+// HasTactic reports whether the technique belongs to the tactic with the
+// given ID.
+func (t *Technique) HasTactic(tacticID string) bool {
+	return slices.Contains(t.Tactics, tacticID)
+}
+
+// SubTechnique returns the sub-technique matching idOrName, or nil. The
+// sub-technique may be given by its suffix such as 001, its full ID such as
+// T1056.001, its name such as Keylogging, or its display name such as
+// Input Capture: Keylogging. Case is ignored.
+func (t *Technique) SubTechnique(idOrName string) *SubTechnique {
+	idOrName = strings.TrimSpace(idOrName)
+	if rest, ok := cutPrefixFold(idOrName, t.ID+"."); ok {
+		idOrName = rest
+	}
+	if rest, ok := cutPrefixFold(idOrName, t.Name+":"); ok {
+		idOrName = strings.TrimSpace(rest)
+	}
+
+	for _, sub := range t.SubTechniques {
+		if strings.EqualFold(sub.ID, idOrName) || strings.EqualFold(sub.Name, idOrName) {
+			return sub
+		}
+	}
+
+	return nil
+}
+
+// LookupMatrix returns the matrix of the given name, ignoring case. It
+// returns an error wrapping ErrUnknown that suggests the closest matrices
+// when there is none.
+func (c *Catalog) LookupMatrix(name string) (*Matrix, error) {
+	if matrix := c.Matrix(name); matrix != nil {
+		return matrix, nil
+	}
+
+	candidates := make([]candidate, 0, len(c.Matrices))
+	for _, matrix := range c.Matrices {
+		candidates = append(candidates, candidate{keys: []string{matrix.Name}, text: matrix.Name})
+	}
+
+	return nil, fmt.Errorf("matrix %q is %w%s", name, ErrUnknown, didYouMean(closest(name, candidates)))
+}
+
+// LookupTactic returns the tactic with the given ID or name, ignoring case.
+// It returns an error wrapping ErrUnknown that suggests the closest tactics
+// when there is none.
+func (m *Matrix) LookupTactic(idOrName string) (*Tactic, error) {
+	if tactic := m.Tactic(idOrName); tactic != nil {
+		return tactic, nil
+	}
+
+	candidates := make([]candidate, 0, len(m.Tactics))
+	for _, tactic := range m.Tactics {
+		candidates = append(candidates, candidate{keys: []string{tactic.ID, tactic.Name}, text: fmt.Sprintf("%s (%s)", tactic.Name, tactic.ID)})
+	}
+
+	return nil, fmt.Errorf("%s tactic %q is %w%s", m.Name, idOrName, ErrUnknown, didYouMean(closest(idOrName, candidates)))
+}
+
+// LookupTechnique returns the technique with the given ID or name, ignoring
+// case. It returns an error wrapping ErrUnknown that suggests the closest
+// techniques when there is none.
+func (m *Matrix) LookupTechnique(idOrName string) (*Technique, error) {
+	if technique := m.Technique(idOrName); technique != nil {
+		return technique, nil
+	}
+
+	candidates := make([]candidate, 0, len(m.Techniques))
+	for _, technique := range m.Techniques {
+		candidates = append(candidates, candidate{keys: []string{technique.ID, technique.Name}, text: fmt.Sprintf("%s (%s)", technique.Name, technique.ID)})
+	}
+
+	return nil, fmt.Errorf("%s technique %q is %w%s", m.Name, idOrName, ErrUnknown, didYouMean(closest(idOrName, candidates)))
+}
+
+// LookupSubTechnique returns the sub-technique matching idOrName as
+// SubTechnique does. It returns an error wrapping ErrUnknown that suggests
+// the closest sub-techniques when there is none.
+func (t *Technique) LookupSubTechnique(idOrName string) (*SubTechnique, error) {
+	if sub := t.SubTechnique(idOrName); sub != nil {
+		return sub, nil
+	}
+
+	candidates := make([]candidate, 0, len(t.SubTechniques))
+	for _, sub := range t.SubTechniques {
+		candidates = append(candidates, candidate{keys: []string{sub.ID, sub.Name}, text: fmt.Sprintf("%s (%s.%s)", sub.Name, t.ID, sub.ID)})
+	}
+
+	return nil, fmt.Errorf("sub-technique %q of %s (%s) is %w%s", idOrName, t.ID, t.Name, ErrUnknown, didYouMean(closest(idOrName, candidates)))
+}
+
+// Resolve looks up a matrix, tactic, technique and optional sub-technique,
+// each given by ID or name, and returns their label. It returns an error
+// wrapping ErrUnknown when one of them is not in the catalog, or ErrMismatch
+// when the technique does not belong to the tactic.
+func (c *Catalog) Resolve(matrixName, tactic, technique, subTechnique string) (Label, error) {
+	matrix, err := c.LookupMatrix(matrixName)
+	if err != nil {
+		return Label{}, err
+	}
+
+	foundTactic, err := matrix.LookupTactic(tactic)
+	if err != nil {
+		return Label{}, err
+	}
+
+	foundTechnique, err := matrix.LookupTechnique(technique)
+	if err != nil {
+		return Label{}, err
+	}
+	if !foundTechnique.HasTactic(foundTactic.ID) {
+		return Label{}, fmt.Errorf("technique %s (%s) %w tactic %s (%s)",
+			foundTechnique.ID, foundTechnique.Name, ErrMismatch, foundTactic.ID, foundTactic.Name)
+	}
+
+	label := Label{Matrix: matrix.Name, Tactic: foundTactic.ID, Technique: foundTechnique.ID}
+	if strings.TrimSpace(subTechnique) == "" {
+		return label, nil
+	}
+
+	foundSub, err := foundTechnique.LookupSubTechnique(subTechnique)
+	if err != nil {
+		return Label{}, err
+	}
+	label.SubTechnique = foundSub.ID
+
+	return label, nil
+}
+
+// cutPrefixFold is strings.CutPrefix ignoring case.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package mitre_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
+)
+
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := mitre.Default()
+	if catalog.Version == "" {
+		t.Error("expected a catalog version")
+	}
+
+	for _, name := range []string{"Enterprise", "Mobile", "ICS"} {
+		matrix := catalog.Matrix(name)
+		if matrix == nil {
+			t.Fatalf("expected the %s matrix", name)
+		}
+
+		for _, technique := range matrix.Techniques {
+			if len(technique.Tactics) == 0 {
+				t.Errorf("%s technique %s has no tactics", name, technique.ID)
+			}
+			for _, id := range technique.Tactics {
+				if matrix.Tactic(id) == nil {
+					t.Errorf("%s technique %s belongs to unknown tactic %s", name, technique.ID, id)
+				}
+			}
+		}
+		for _, tactic := range matrix.Tactics {
+			if len(matrix.TacticTechniques(tactic.ID)) == 0 {
+				t.Errorf("%s tactic %s has no techniques", name, tactic.ID)
+			}
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                                    string
+		matrix, tactic, technique, subTechnique string
+		want                                    string
+	}{
+		{"names", "Enterprise", "Collection", "Input Capture", "Keylogging", "Enterprise.TA0009.T1056.001"},
+		{"ids", "Enterprise", "TA0009", "T1056", "001", "Enterprise.TA0009.T1056.001"},
+		{"case", "enterprise", "collection", "input capture", "keylogging", "Enterprise.TA0009.T1056.001"},
+		{"full sub-technique id", "Enterprise", "TA0006", "T1056", "T1056.001", "Enterprise.TA0006.T1056.001"},
+		{"display name", "Enterprise", "Collection", "Input Capture", "Input Capture: Keylogging", "Enterprise.TA0009.T1056.001"},
+		{"no sub-technique", "Enterprise", "Initial Access", "Phishing", "", "Enterprise.TA0001.T1566"},
+		{"mobile", "Mobile", "Initial Access", "Phishing", "", "Mobile.TA0027.T1660"},
+		{"ics", "ICS", "Inhibit Response Function", "Alarm Suppression", "", "ICS.TA0107.T0878"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			label, err := mitre.Default().Resolve(tt.matrix, tt.tactic, tt.technique, tt.subTechnique)
+			if err != nil {
+				t.Fatalf("Resolve returned error: %v", err)
+			}
+			if got := label.String(); got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                                    string
+		matrix, tactic, technique, subTechnique string
+		want                                    error
+	}{
+		{"unknown matrix", "PRE", "Collection", "Input Capture", "", mitre.ErrUnknown},
+		{"unknown tactic", "Enterprise", "TA9999", "T1056", "", mitre.ErrUnknown},
+		{"unknown technique", "Enterprise", "Collection", "T0000", "", mitre.ErrUnknown},
+		{"unknown sub-technique", "Enterprise", "Collection", "T1056", "009", mitre.ErrUnknown},
+		{"technique of another tactic", "Enterprise", "Impact", "Input Capture", "", mitre.ErrMismatch},
+		{"technique of another matrix", "ICS", "Collection", "T1056", "", mitre.ErrUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := mitre.Default().Resolve(tt.matrix, tt.tactic, tt.technique, tt.subTechnique)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Resolve() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLabel(t *testing.T) {
+	t.Parallel()
+
+	label, err := mitre.ParseLabel("Enterprise.TA0009.T1056.001")
+	if err != nil {
+		t.Fatalf("ParseLabel returned error: %v", err)
+	}
+	want := mitre.Label{Matrix: "Enterprise", Tactic: "TA0009", Technique: "T1056", SubTechnique: "001"}
+	if label != want {
+		t.Errorf("ParseLabel() = %+v, want %+v", label, want)
+	}
+
+	for _, value := range []string{"", "Enterprise.TA0009", "enterprise.TA0009.T1056", "Enterprise.TA0009.T1056.01"} {
+		if _, err := mitre.ParseLabel(value); !errors.Is(err, mitre.ErrLabelFormat) {
+			t.Errorf("ParseLabel(%q) error = %v, want %v", value, err, mitre.ErrLabelFormat)
+		}
+	}
+}
+
+func TestCheckLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		label string
+		want  error
+	}{
+		{"Enterprise.TA0009.T1056.001", nil},
+		{"Enterprise.TA0006.T1056", nil},
+		{"ICS.TA0105.T0829", nil},
+		{"Enterprise.TA9999.T0000", mitre.ErrUnknown},
+		{"Enterprise.TA0009.T0000", mitre.ErrUnknown},
+		{"Enterprise.TA0009.T1056.009", mitre.ErrUnknown},
+		{"Enterprise.TA0040.T1056", mitre.ErrMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			t.Parallel()
+
+			label, err := mitre.ParseLabel(tt.label)
+			if err != nil {
+				t.Fatalf("ParseLabel returned error: %v", err)
+			}
+			if err := mitre.Default().CheckLabel(label); !errors.Is(err, tt.want) {
+				t.Errorf("CheckLabel() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupSuggestions(t *testing.T) {
+	t.Parallel()
+
+	catalog := mitre.Default()
+	enterprise := catalog.Matrix("Enterprise")
+	inputCapture := enterprise.Technique("T1056")
+
+	tests := []struct {
+		name   string
+		lookup func() error
+		want   string
+	}{
+		{"matrix", func() error { _, err := catalog.LookupMatrix("Enterprize"); return err }, "did you mean Enterprise"},
+		{"tactic", func() error { _, err := enterprise.LookupTactic("Colection"); return err }, "did you mean Collection (TA0009)"},
+		{"technique", func() error { _, err := enterprise.LookupTechnique("Phising"); return err }, "did you mean Phishing (T1566)"},
+		{"sub-technique", func() error { _, err := inputCapture.LookupSubTechnique("Keyloging"); return err }, "did you mean Keylogging (T1056.001)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.lookup()
+			if !errors.Is(err, mitre.ErrUnknown) {
+				t.Fatalf("lookup error = %v, want %v", err, mitre.ErrUnknown)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("lookup error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultAlertLimit is the number of alerts returned when limit is not set.
+const defaultAlertLimit = 1000
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &alertsDataSource{}
+	_ datasource.DataSourceWithConfigure = &alertsDataSource{}
+)
+
+// Configure adds the provider configured client to the data source.
+func (d *alertsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+// AlertsDataSource is a helper function to simplify the provider implementation.
+func AlertsDataSource() datasource.DataSource {
+	return &alertsDataSource{}
+}
+
+// alertsDataSource is the data source implementation.
+type alertsDataSource struct {
+	client *armis.Client
+}
+
+// Metadata returns the data source type name.
+func (d *alertsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alerts"
+}
+
+// Schema defines the schema for the alerts data source.
+func (d *alertsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves Armis alerts. Use policy_ids, severities, statuses and time_frame to filter results.",
+		Attributes: map[string]schema.Attribute{
+			"policy_ids": schema.SetAttribute{
+				Description: "Optional filter to include only alerts raised by these policies, such as the id of an armis_policy.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"severities": schema.SetAttribute{
+				Description: "Optional filter to include only alerts of these severities. Valid options include 'low', 'medium', 'high', and 'critical'.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("low", "medium", "high", "critical")),
+				},
+			},
+			"statuses": schema.SetAttribute{
+				Description: "Optional filter to include only alerts in these statuses. Valid options include 'unhandled', 'suppressed', and 'resolved'.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("unhandled", "suppressed", "resolved")),
+				},
+			},
+			"time_frame": schema.StringAttribute{
+				Description: "Optional filter to include only alerts raised within this time frame, such as '1 Hour' or '7 Days'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(verify.ASQTimeFramePattern, `must be a number followed by a unit such as Hours, Days or Weeks, for example "1 Day" or "7 Days"`),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of alerts to return. Defaults to %d.", defaultAlertLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total": schema.Int64Attribute{
+				Description: "The total number of alerts matching the filters, which may exceed the number returned.",
+				Computed:    true,
+			},
+			"alerts": schema.ListNestedAttribute{
+				Description: "A computed list of alerts. Each object in the list contains detailed information about an alert.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "A unique identifier for the alert.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the alert.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the alert.",
+							Computed:    true,
+						},
+						"severity": schema.StringAttribute{
+							Description: "The severity of the alert.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the alert.",
+							Computed:    true,
+						},
+						"policy_id": schema.StringAttribute{
+							Description: "The ID of the policy that raised the alert.",
+							Computed:    true,
+						},
+						"affected_device_count": schema.Int64Attribute{
+							Description: "The number of devices affected by the alert.",
+							Computed:    true,
+						},
+						"time": schema.StringAttribute{
+							Description: "The time the alert was raised.",
+							Computed:    true,
+						},
+						"last_update_time": schema.StringAttribute{
+							Description: "The time the alert was last updated.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// alertsDataSourceModel maps the data source schema data.
+type alertsDataSourceModel struct {
+	PolicyIDs  []types.String `tfsdk:"policy_ids"`
+	Severities []types.String `tfsdk:"severities"`
+	Statuses   []types.String `tfsdk:"statuses"`
+	TimeFrame  types.String   `tfsdk:"time_frame"`
+	Limit      types.Int64    `tfsdk:"limit"`
+	Total      types.Int64    `tfsdk:"total"`
+	Alerts     []alertModel   `tfsdk:"alerts"`
+}
+
+// alertModel maps the alert schema data.
+type alertModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Title               types.String `tfsdk:"title"`
+	Type                types.String `tfsdk:"type"`
+	Severity            types.String `tfsdk:"severity"`
+	Status              types.String `tfsdk:"status"`
+	PolicyID            types.String `tfsdk:"policy_id"`
+	AffectedDeviceCount types.Int64  `tfsdk:"affected_device_count"`
+	Time                types.String `tfsdk:"time"`
+	LastUpdateTime      types.String `tfsdk:"last_update_time"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *alertsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state alertsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultAlertLimit
+	if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	asq := buildAlertsASQ(
+		typesStringSliceToStrings(state.PolicyIDs),
+		typesStringSliceToStrings(state.Severities),
+		typesStringSliceToStrings(state.Statuses),
+		state.TimeFrame.ValueString(),
+	)
+
+	// Page through the matching alerts
+	apiAlerts, total, err := searchAlerts(ctx, d.client, asq, limit)
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Alerts", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetched alerts from Armis API", map[string]any{
+		"asq":          asq,
+		"limit":        limit,
+		"total_count":  total,
+		"return_count": len(apiAlerts),
+	})
+
+	// Map response body to model
+	alerts := make([]alertModel, 0, len(apiAlerts))
+	for _, alert := range apiAlerts {
+		alerts = append(alerts, alertModel{
+			ID:                  types.StringValue(strconv.Itoa(alert.AlertID)),
+			Title:               types.StringValue(alert.Title),
+			Type:                types.StringValue(alert.Type),
+			Severity:            types.StringValue(strings.ToLower(alert.Severity)),
+			Status:              types.StringValue(strings.ToLower(alert.Status)),
+			PolicyID:            types.StringValue(alert.PolicyID),
+			AffectedDeviceCount: types.Int64Value(int64(len(alert.DeviceIDs))),
+			Time:                types.StringValue(alert.Time),
+			LastUpdateTime:      types.StringValue(alert.LastAlertUpdateTime),
+		})
+	}
+
+	// Save data into Terraform state
+	state.Total = types.Int64Value(int64(total))
+	state.Alerts = alerts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
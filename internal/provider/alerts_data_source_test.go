@@ -0,0 +1,52 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_AlertsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertsDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.armis_alerts.test", "total"),
+					resource.TestCheckResourceAttrSet("data.armis_alerts.test", "alerts.#"),
+				),
+			},
+			{
+				Config: testAccAlertsDataSourceFilteredConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.armis_alerts.filtered", "time_frame", "1 Day"),
+					resource.TestCheckResourceAttrSet("data.armis_alerts.filtered", "total"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAlertsDataSourceConfig() string {
+	return `
+data "armis_alerts" "test" {
+  limit = 10
+}
+`
+}
+
+func testAccAlertsDataSourceFilteredConfig() string {
+	return `
+data "armis_alerts" "filtered" {
+  severities = ["high", "critical"]
+  statuses   = ["unhandled"]
+  time_frame = "1 Day"
+  limit      = 10
+}
+`
+}
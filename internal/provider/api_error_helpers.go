@@ -5,6 +5,7 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
 
@@ -21,24 +23,57 @@ func appendAPIError(diags *diag.Diagnostics, title string, err error) {
 		return
 	}
 
-	var apiErr *armis.APIError
-	if errors.As(err, &apiErr) {
-		body := strings.TrimSpace(string(apiErr.Body))
-		if body == "" {
-			body = "(empty response body)"
-		} else if json.Valid(apiErr.Body) {
-			var pretty bytes.Buffer
-			if indentErr := json.Indent(&pretty, apiErr.Body, "", "  "); indentErr == nil {
-				body = pretty.String()
-			}
+	if errors.Is(err, context.DeadlineExceeded) {
+		diags.AddError(
+			title,
+			fmt.Sprintf("The operation did not finish before its deadline: %v\n\n"+
+				"The Armis API may be slow or rate limiting requests. Increase the matching value in the "+
+				"resource's timeouts block (for example timeouts { create = \"30m\" }) and try again.", err),
+		)
+		return
+	}
+
+	var retryErr *transport.RetryError
+	if errors.As(err, &retryErr) {
+		if retryErr.StatusCode == 0 {
+			diags.AddError(title, fmt.Sprintf("API error after %d attempts: %v", retryErr.Attempts, retryErr.Err))
+			return
 		}
 
 		diags.AddError(
 			title,
-			fmt.Sprintf("API error %d %s\nResponse body:\n%s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode), body),
+			fmt.Sprintf("API error %d %s after %d attempts\nResponse body:\n%s",
+				retryErr.StatusCode, http.StatusText(retryErr.StatusCode), retryErr.Attempts, formatResponseBody(retryErr.Body)),
+		)
+		return
+	}
+
+	var apiErr *armis.APIError
+	if errors.As(err, &apiErr) {
+		diags.AddError(
+			title,
+			fmt.Sprintf("API error %d %s\nResponse body:\n%s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode), formatResponseBody(apiErr.Body)),
 		)
 		return
 	}
 
 	diags.AddError(title, fmt.Sprintf("API error: %v", err))
 }
+
+// formatResponseBody pretty-prints JSON response bodies and substitutes a
+// placeholder for empty ones.
+func formatResponseBody(raw []byte) string {
+	body := strings.TrimSpace(string(raw))
+	if body == "" {
+		return "(empty response body)"
+	}
+
+	if json.Valid(raw) {
+		var pretty bytes.Buffer
+		if indentErr := json.Indent(&pretty, raw, "", "  "); indentErr == nil {
+			return pretty.String()
+		}
+	}
+
+	return body
+}
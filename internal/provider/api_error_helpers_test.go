@@ -4,12 +4,14 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
 
@@ -469,3 +471,112 @@ func TestAppendAPIError_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestAppendAPIError_RetryError tests appendAPIError with exhausted retries.
+func TestAppendAPIError_RetryError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		err            error
+		validateDetail func(t *testing.T, detail string)
+	}{
+		{
+			name: "exhausted on status code",
+			err: &transport.RetryError{
+				Attempts:   4,
+				StatusCode: 429,
+				Body:       []byte(`{"message":"rate limited"}`),
+			},
+			validateDetail: func(t *testing.T, detail string) {
+				if !strings.Contains(detail, "API error 429 Too Many Requests after 4 attempts") {
+					t.Errorf("Expected status and attempt count in detail, got: %s", detail)
+				}
+				if !strings.Contains(detail, `"message": "rate limited"`) {
+					t.Errorf("Expected pretty-printed JSON in detail, got: %s", detail)
+				}
+			},
+		},
+		{
+			name: "exhausted on transport error",
+			err: &transport.RetryError{
+				Attempts: 3,
+				Err:      errors.New("connection reset by peer"), //nolint:err113 // test fixture
+			},
+			validateDetail: func(t *testing.T, detail string) {
+				if !strings.Contains(detail, "API error after 3 attempts: connection reset by peer") {
+					t.Errorf("Expected attempt count and cause in detail, got: %s", detail)
+				}
+			},
+		},
+		{
+			name: "wrapped by the SDK",
+			err: fmt.Errorf("error making request: %w", &transport.RetryError{
+				Attempts:   2,
+				StatusCode: 503,
+			}),
+			validateDetail: func(t *testing.T, detail string) {
+				if !strings.Contains(detail, "API error 503 Service Unavailable after 2 attempts") {
+					t.Errorf("Expected wrapped retry error to be unwrapped, got: %s", detail)
+				}
+				if !strings.Contains(detail, "(empty response body)") {
+					t.Errorf("Expected '(empty response body)' in detail, got: %s", detail)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var diags diag.Diagnostics
+			appendAPIError(&diags, "Error reading policy", tt.err)
+
+			if len(diags) != 1 {
+				t.Fatalf("Expected 1 diagnostic, got %d", len(diags))
+			}
+			tt.validateDetail(t, diags[0].Detail())
+		})
+	}
+}
+
+// TestAppendAPIError_DeadlineExceeded tests appendAPIError when an operation runs past its timeout.
+func TestAppendAPIError_DeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "bare deadline",
+			err:  context.DeadlineExceeded,
+		},
+		{
+			name: "wrapped by the SDK",
+			err:  fmt.Errorf("error making request: %w", context.DeadlineExceeded),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var diags diag.Diagnostics
+			appendAPIError(&diags, "Error creating policy", tt.err)
+
+			if len(diags) != 1 {
+				t.Fatalf("Expected 1 diagnostic, got %d", len(diags))
+			}
+			if diags[0].Summary() != "Error creating policy" {
+				t.Errorf("Expected summary 'Error creating policy', got '%s'", diags[0].Summary())
+			}
+			detail := diags[0].Detail()
+			if !strings.Contains(detail, "did not finish before its deadline") {
+				t.Errorf("Expected deadline explanation in detail, got: %s", detail)
+			}
+			if !strings.Contains(detail, "timeouts block") {
+				t.Errorf("Expected hint about the timeouts block in detail, got: %s", detail)
+			}
+		})
+	}
+}
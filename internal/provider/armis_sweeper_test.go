@@ -23,4 +23,7 @@ func init() {
 	resource.AddTestSweepers("roles", sweep.SweepArmisRoles("roles"))
 	resource.AddTestSweepers("policies", sweep.SweepArmisPolicies("policies"))
 	resource.AddTestSweepers("reports", sweep.SweepArmisReports("reports"))
+	resource.AddTestSweepers("sites", sweep.SweepArmisSites("sites"))
+	resource.AddTestSweepers("boundaries", sweep.SweepArmisBoundaries("boundaries"))
+	resource.AddTestSweepers("lists", sweep.SweepArmisLists("lists"))
 }
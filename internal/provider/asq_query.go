@@ -0,0 +1,76 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// cveIDPattern matches CVE identifiers such as CVE-2021-44228.
+var cveIDPattern = regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`)
+
+// buildAlertsASQ builds the ASQ query selecting the alerts that match the
+// data source filters. Empty filters are left out.
+func buildAlertsASQ(policyIDs, severities, statuses []string, timeFrame string) string {
+	parts := []string{"in:alerts"}
+	if timeFrame != "" {
+		parts = append(parts, "timeFrame:"+asqQuote(timeFrame))
+	}
+	if len(policyIDs) > 0 {
+		parts = append(parts, "policyId:"+asqValues(policyIDs, nil))
+	}
+	if len(severities) > 0 {
+		parts = append(parts, "severity:"+asqValues(severities, capitalize))
+	}
+	if len(statuses) > 0 {
+		parts = append(parts, "status:"+asqValues(statuses, capitalize))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// buildVulnerabilitiesASQ builds the ASQ query selecting the given CVEs, or
+// every vulnerability when cveIDs is empty.
+func buildVulnerabilitiesASQ(cveIDs []string) string {
+	if len(cveIDs) == 0 {
+		return "in:vulnerabilities"
+	}
+
+	return "in:vulnerabilities cveUid:" + asqValues(cveIDs, nil)
+}
+
+// asqValues renders values as a comma separated ASQ value list, applying
+// format to each value first when it is set.
+func asqValues(values []string, format func(string) string) string {
+	rendered := make([]string, len(values))
+	for i, value := range values {
+		if format != nil {
+			value = format(value)
+		}
+		rendered[i] = asqQuote(value)
+	}
+
+	return strings.Join(rendered, ",")
+}
+
+// asqQuote quotes value when it holds characters that end an ASQ value.
+func asqQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t,:()\"") {
+		return value
+	}
+
+	return strconv.Quote(value)
+}
+
+// capitalize upper-cases the first letter of value, turning the lower-case
+// values accepted by the schema into the ones used by ASQ.
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+
+	return strings.ToUpper(value[:1]) + value[1:]
+}
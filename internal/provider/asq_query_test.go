@@ -0,0 +1,102 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+)
+
+// TestBuildAlertsASQ tests turning the alerts data source filters into ASQ.
+func TestBuildAlertsASQ(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		policyIDs  []string
+		severities []string
+		statuses   []string
+		timeFrame  string
+		expected   string
+	}{
+		{
+			name:     "no filters",
+			expected: "in:alerts",
+		},
+		{
+			name:      "time frame",
+			timeFrame: "7 Days",
+			expected:  `in:alerts timeFrame:"7 Days"`,
+		},
+		{
+			name:      "singular time frame",
+			timeFrame: "1 Day",
+			expected:  `in:alerts timeFrame:"1 Day"`,
+		},
+		{
+			name:       "single values",
+			policyIDs:  []string{"12"},
+			severities: []string{"low"},
+			statuses:   []string{"suppressed"},
+			expected:   "in:alerts policyId:12 severity:Low status:Suppressed",
+		},
+		{
+			name:       "all filters",
+			policyIDs:  []string{"12", "34"},
+			severities: []string{"high", "critical"},
+			statuses:   []string{"unhandled"},
+			timeFrame:  "1 Hours",
+			expected:   `in:alerts timeFrame:"1 Hours" policyId:12,34 severity:High,Critical status:Unhandled`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := buildAlertsASQ(tt.policyIDs, tt.severities, tt.statuses, tt.timeFrame)
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+			if _, err := verify.ParseASQ(got); err != nil {
+				t.Errorf("Expected %q to be valid ASQ, got: %s", got, err)
+			}
+		})
+	}
+}
+
+// TestASQQuote tests quoting of ASQ values.
+func TestASQQuote(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"PLC":                `PLC`,
+		"Kansas City Plant":  `"Kansas City Plant"`,
+		`Plant "A"`:          `"Plant \"A\""`,
+		"a,b":                `"a,b"`,
+		"":                   `""`,
+		"ipAddress:10.0.0.1": `"ipAddress:10.0.0.1"`,
+	}
+
+	for value, expected := range tests {
+		if got := asqQuote(value); got != expected {
+			t.Errorf("asqQuote(%q): expected %s, got %s", value, expected, got)
+		}
+	}
+}
+
+// TestBuildVulnerabilitiesASQ tests turning a CVE list into ASQ.
+func TestBuildVulnerabilitiesASQ(t *testing.T) {
+	t.Parallel()
+
+	if got := buildVulnerabilitiesASQ(nil); got != "in:vulnerabilities" {
+		t.Errorf("Expected all vulnerabilities, got %q", got)
+	}
+
+	expected := "in:vulnerabilities cveUid:CVE-2021-44228,CVE-2023-4966"
+	if got := buildVulnerabilitiesASQ([]string{"CVE-2021-44228", "CVE-2023-4966"}); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
@@ -28,17 +28,17 @@ func (d *boundariesDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
 
 // BoundaryDataSource is a helper function to simplify the provider implementation.
@@ -141,10 +141,7 @@ func (d *boundariesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		// Fetch a specific boundary by ID
 		boundary, err := d.client.GetBoundaryByID(ctx, config.BoundaryID.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis Boundary",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis Boundary", err)
 			return
 		}
 
@@ -176,10 +173,7 @@ func (d *boundariesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		// Fetch all boundaries
 		allBoundaries, err := d.client.GetBoundaries(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis Boundaries",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis Boundaries", err)
 			return
 		}
 
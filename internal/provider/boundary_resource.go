@@ -0,0 +1,384 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &boundaryResource{}
+	_ resource.ResourceWithConfigure   = &boundaryResource{}
+	_ resource.ResourceWithImportState = &boundaryResource{}
+)
+
+type boundaryResource struct {
+	client   *armis.Client
+	readOnly bool
+}
+
+func BoundaryResource() resource.Resource {
+	return &boundaryResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *boundaryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+// Metadata returns the resource type name.
+func (r *boundaryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_boundary"
+}
+
+// Schema defines the schema for the boundary resource.
+func (r *boundaryResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides an Armis boundary resource.
+
+Boundaries segment the network by matching devices with AQL conditions. The rule has the same shape as the one exposed by the ` + "`armis_boundary`" + ` data source.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The unique identifier for the boundary.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the boundary.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"affected_sites": schema.StringAttribute{
+				Optional:    true,
+				Description: "The sites affected by this boundary, for example the name of an `armis_site`.",
+			},
+			"rule_aql": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The AQL rule configuration for the boundary. At least one of `and` and `or` must be set.",
+				Attributes: map[string]schema.Attribute{
+					"and": schema.ListAttribute{
+						Optional:    true,
+						Description: "List of AND conditions in the AQL rule.",
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("or")),
+						},
+					},
+					"or": schema.ListAttribute{
+						Optional:    true,
+						Description: "List of OR conditions in the AQL rule.",
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// boundaryResourceModel maps the resource schema data.
+type boundaryResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	AffectedSites types.String   `tfsdk:"affected_sites"`
+	RuleAQL       ruleAQLModel   `tfsdk:"rule_aql"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *boundaryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_boundary")
+		return
+	}
+
+	var plan boundaryResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Creating boundary in Armis", map[string]any{"boundary_name": plan.Name.ValueString()})
+
+	boundary, err := r.client.CreateBoundary(ctx, buildArmisBoundary(plan))
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error creating boundary %q", plan.Name.ValueString()), err)
+		return
+	}
+	if boundary.ID == 0 {
+		resp.Diagnostics.AddError(
+			"Error Creating Armis Boundary",
+			fmt.Sprintf("Boundary %q was created but the API did not return its ID", plan.Name.ValueString()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Boundary created successfully", map[string]any{
+		"boundary_id":   boundary.ID,
+		"boundary_name": boundary.Name,
+	})
+
+	plan = mapBoundaryToModel(boundary, plan)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read reads the resource state from the API.
+func (r *boundaryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state boundaryResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Reading boundary from Armis", map[string]any{"boundary_id": state.ID.ValueString()})
+
+	boundary, err := r.client.GetBoundaryByID(ctx, state.ID.ValueString())
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "Boundary not found, removing from state", map[string]any{
+				"boundary_id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error reading boundary %s", state.ID.ValueString()), err)
+		return
+	}
+
+	if boundary.ID == 0 {
+		tflog.Warn(ctx, "Boundary not found, removing from state", map[string]any{
+			"boundary_id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite the state with the boundary as it is in Armis so that
+	// changes made outside of Terraform show up as drift.
+	state = mapBoundaryToModel(boundary, state)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates an existing boundary in Armis.
+func (r *boundaryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_boundary")
+		return
+	}
+
+	var plan boundaryResourceModel
+	var state boundaryResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	boundaryID := state.ID.ValueString()
+
+	tflog.Info(ctx, "Updating boundary in Armis", map[string]any{
+		"boundary_id":   boundaryID,
+		"boundary_name": plan.Name.ValueString(),
+	})
+
+	boundary, err := r.client.UpdateBoundary(ctx, boundaryID, buildArmisBoundary(plan))
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error updating boundary %s", boundaryID), err)
+		return
+	}
+
+	tflog.Info(ctx, "Boundary updated successfully", map[string]any{
+		"boundary_id":   boundaryID,
+		"boundary_name": boundary.Name,
+	})
+
+	plan.ID = state.ID
+	if boundary.ID != 0 {
+		plan = mapBoundaryToModel(boundary, plan)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource.
+func (r *boundaryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_boundary")
+		return
+	}
+
+	var state boundaryResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Deleting boundary from Armis", map[string]any{"boundary_id": state.ID.ValueString()})
+
+	success, err := r.client.DeleteBoundary(ctx, state.ID.ValueString())
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "Boundary already deleted", map[string]any{"boundary_id": state.ID.ValueString()})
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error deleting boundary %s", state.ID.ValueString()), err)
+		return
+	}
+
+	if !success {
+		resp.Diagnostics.AddError(
+			"Error Deleting Armis Boundary",
+			"Could not delete boundary: operation returned unsuccessful status",
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Boundary deleted successfully", map[string]any{"boundary_id": state.ID.ValueString()})
+}
+
+// ImportState imports an existing boundary into Terraform state.
+func (r *boundaryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// buildArmisBoundary converts the Terraform model to SDK boundary settings.
+func buildArmisBoundary(plan boundaryResourceModel) armis.BoundarySettings {
+	return armis.BoundarySettings{
+		Name:          plan.Name.ValueString(),
+		AffectedSites: plan.AffectedSites.ValueString(),
+		RuleAQL: armis.Rules2{
+			And: typesStringSliceToStrings(plan.RuleAQL.And),
+			Or:  typesStringSliceToStrings(plan.RuleAQL.Or),
+		},
+	}
+}
+
+// mapBoundaryToModel overlays the boundary returned by the API on prior.
+// Empty condition lists map to null, matching an unset and or or.
+func mapBoundaryToModel(boundary armis.BoundarySettings, prior boundaryResourceModel) boundaryResourceModel {
+	model := prior
+	model.ID = types.StringValue(strconv.Itoa(boundary.ID))
+	model.Name = types.StringValue(boundary.Name)
+	model.AffectedSites = optionalStringValue(prior.AffectedSites, boundary.AffectedSites)
+	model.RuleAQL = ruleAQLModel{
+		And: stringsToTypesStringSlice(boundary.RuleAQL.And),
+		Or:  stringsToTypesStringSlice(boundary.RuleAQL.Or),
+	}
+
+	return model
+}
+
+// stringsToTypesStringSlice converts a slice of strings to a slice of
+// types.String, returning nil for an empty slice.
+func stringsToTypesStringSlice(values []string) []types.String {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]types.String, len(values))
+	for i, value := range values {
+		result[i] = types.StringValue(value)
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAcc_BoundaryResource tests creating, updating and importing a boundary.
+func TestAcc_BoundaryResource(t *testing.T) {
+	resourceName := "armis_boundary.test"
+	rName := strings.ToLower(acctest.RandomWithPrefix("tfacc-boundary"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBoundaryResourceConfig(rName, `["ipAddress:10.0.0.0/8"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "rule_aql.and.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rule_aql.and.0", "ipAddress:10.0.0.0/8"),
+					resource.TestCheckNoResourceAttr(resourceName, "rule_aql.or"),
+				),
+			},
+			// Test updating the rule in place
+			{
+				Config: testAccBoundaryResourceConfig(rName, `["ipAddress:10.0.0.0/8", "site:\"Kansas City Plant\""]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule_aql.and.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rule_aql.and.1", `site:"Kansas City Plant"`),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccBoundaryResourceConfig(name, and string) string {
+	return fmt.Sprintf(`
+resource "armis_boundary" "test" {
+  name = %q
+
+  rule_aql = {
+    and = %s
+  }
+}
+`, name, and)
+}
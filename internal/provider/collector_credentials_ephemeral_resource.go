@@ -0,0 +1,146 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &collectorCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &collectorCredentialsEphemeralResource{}
+)
+
+type collectorCredentialsEphemeralResource struct {
+	client *armis.Client
+}
+
+func CollectorCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &collectorCredentialsEphemeralResource{}
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *collectorCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *collectorCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collector_credentials"
+}
+
+// Schema defines the schema for the collector credentials ephemeral resource.
+func (r *collectorCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Retrieves the credentials of an Armis collector without storing them in Terraform state or plan files.
+
+The values are only available during the run that opens the ephemeral resource, so they can be passed to
+a secrets manager, cloud-init or another ephemeral-aware attribute.
+`,
+		Attributes: map[string]schema.Attribute{
+			"collector_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the collector whose credentials are retrieved.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"license_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The license key associated with the collector.",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The password associated with the collector.",
+			},
+			"user": schema.StringAttribute{
+				Computed:    true,
+				Description: "The username associated with the collector.",
+			},
+		},
+	}
+}
+
+// collectorCredentialsEphemeralResourceModel maps the ephemeral resource schema data.
+type collectorCredentialsEphemeralResourceModel struct {
+	CollectorID types.String `tfsdk:"collector_id"`
+	LicenseKey  types.String `tfsdk:"license_key"`
+	Password    types.String `tfsdk:"password"`
+	User        types.String `tfsdk:"user"`
+}
+
+// Open fetches the collector credentials and returns them as the ephemeral result.
+func (r *collectorCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config collectorCredentialsEphemeralResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collectorID := config.CollectorID.ValueString()
+	tflog.Info(ctx, "Retrieving collector credentials", map[string]any{"collector_id": collectorID})
+
+	credentials, err := r.client.GetCollectorCredentials(ctx, collectorID)
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("collector_id"),
+				"Collector Not Found",
+				fmt.Sprintf("No collector with ID %q exists in Armis.", collectorID),
+			)
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error retrieving credentials for collector %s", collectorID), err)
+		return
+	}
+	if credentials == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("collector_id"),
+			"Collector Not Found",
+			fmt.Sprintf("No credentials were returned for collector %q.", collectorID),
+		)
+		return
+	}
+
+	config.LicenseKey = types.StringValue(credentials.LicenseKey)
+	config.Password = types.StringValue(credentials.Password)
+	config.User = types.StringValue(credentials.User)
+
+	diags = resp.Result.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_CollectorCredentialsEphemeralResource(t *testing.T) {
+	resourceName := "armis_collector.test"
+
+	rName := strings.ToLower(acctest.RandomWithPrefix("tfacc-collector-creds"))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectorCredentialsEphemeralResourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "persist_credentials", "false"),
+					resource.TestCheckNoResourceAttr(resourceName, "license_key"),
+					resource.TestCheckNoResourceAttr(resourceName, "password"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCollectorCredentialsEphemeralResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "armis_collector" "test" {
+  name                = %q
+  deployment_type     = "OVA"
+  persist_credentials = false
+}
+
+ephemeral "armis_collector_credentials" "test" {
+  collector_id = armis_collector.test.id
+}
+`, name)
+}
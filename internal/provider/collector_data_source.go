@@ -29,17 +29,17 @@ func (d *collectorsDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
 
 // CollectorDataSource is a helper function to simplify the provider implementation.
@@ -164,10 +164,7 @@ func (d *collectorsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	collectors, err := d.client.GetCollectors(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Armis Collectors",
-			err.Error(),
-		)
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Collectors", err)
 		return
 	}
 
@@ -10,10 +10,12 @@ import (
 
 	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -29,7 +31,8 @@ var (
 )
 
 type collectorResource struct {
-	client *armis.Client
+	client   *armis.Client
+	readOnly bool
 }
 
 func CollectorResource() resource.Resource {
@@ -42,16 +45,17 @@ func (r *collectorResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
 }
 
 // Metadata returns the resource type name.
@@ -86,12 +90,20 @@ func (r *collectorResource) Schema(ctx context.Context, req resource.SchemaReque
 			"license_key": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
-				Description: "The license key associated with the collector.",
+				Description: "The license key associated with the collector. Null when `persist_credentials` is `false`.",
 			},
 			"password": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
-				Description: "The password associated with the collector.",
+				Description: "The password associated with the collector. Null when `persist_credentials` is `false`.",
+			},
+			"persist_credentials": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				Description: "Whether the license key and password returned when the collector is created are stored in state. " +
+					"Set to false to keep them out of state and read them with the armis_collector_credentials ephemeral resource instead. " +
+					"Defaults to true.",
 			},
 			"user": schema.StringAttribute{
 				Computed:    true,
@@ -103,21 +115,31 @@ func (r *collectorResource) Schema(ctx context.Context, req resource.SchemaReque
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
 // collectorResourceModel maps the resource schema data.
 type collectorResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	DeploymentType types.String `tfsdk:"deployment_type"`
-	LicenseKey     types.String `tfsdk:"license_key"`
-	Password       types.String `tfsdk:"password"`
-	User           types.String `tfsdk:"user"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	DeploymentType     types.String   `tfsdk:"deployment_type"`
+	LicenseKey         types.String   `tfsdk:"license_key"`
+	Password           types.String   `tfsdk:"password"`
+	PersistCredentials types.Bool     `tfsdk:"persist_credentials"`
+	User               types.String   `tfsdk:"user"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *collectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_collector")
+		return
+	}
+
 	var plan collectorResourceModel
 	tflog.Info(ctx, "Creating collector")
 
@@ -128,6 +150,15 @@ func (r *collectorResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	collector := armis.CreateCollectorSettings{
 		Name:           plan.Name.ValueString(),
 		DeploymentType: plan.DeploymentType.ValueString(),
@@ -142,9 +173,15 @@ func (r *collectorResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Map the response to Terraform state
 	plan.ID = types.StringValue(strconv.Itoa(newCollector.CollectorID))
-	plan.LicenseKey = types.StringValue(newCollector.LicenseKey)
-	plan.Password = types.StringValue(newCollector.Password)
 	plan.User = types.StringValue(newCollector.User)
+	if plan.PersistCredentials.ValueBool() {
+		plan.LicenseKey = types.StringValue(newCollector.LicenseKey)
+		plan.Password = types.StringValue(newCollector.Password)
+	} else {
+		tflog.Info(ctx, "Not persisting collector credentials in state", map[string]any{"collector_id": plan.ID.ValueString()})
+		plan.LicenseKey = types.StringNull()
+		plan.Password = types.StringNull()
+	}
 
 	// Save the state
 	tflog.Info(ctx, "Setting state for collector")
@@ -163,6 +200,15 @@ func (r *collectorResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed collector value from Armis
 	collector, err := r.client.GetCollectorByID(ctx, state.ID.ValueString())
 	if err != nil {
@@ -182,6 +228,12 @@ func (r *collectorResource) Read(ctx context.Context, req resource.ReadRequest,
 	state.Name = types.StringValue(collector.Name)
 	state.ID = types.StringValue(strconv.Itoa(collector.CollectorNumber))
 
+	// Imported collectors and state written by earlier provider versions do
+	// not carry persist_credentials, so fall back to its default.
+	if state.PersistCredentials.IsNull() {
+		state.PersistCredentials = types.BoolValue(true)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -191,6 +243,11 @@ func (r *collectorResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *collectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_collector")
+		return
+	}
+
 	// Retrieve values from plan
 	var plan collectorResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -207,6 +264,15 @@ func (r *collectorResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Validate that the collector ID is available
 	if state.ID.IsNull() || state.ID.ValueString() == "" {
 		resp.Diagnostics.AddError(
@@ -251,9 +317,16 @@ func (r *collectorResource) Update(ctx context.Context, req resource.UpdateReque
 	plan.ID = types.StringValue(strconv.Itoa(updatedCollector.CollectorNumber))
 	plan.Name = types.StringValue(updatedCollector.Name)
 	plan.DeploymentType = types.StringValue(plan.DeploymentType.ValueString())
-	plan.LicenseKey = types.StringValue(state.LicenseKey.ValueString())
-	plan.Password = types.StringValue(state.Password.ValueString())
 	plan.User = types.StringValue(state.User.ValueString())
+	if plan.PersistCredentials.ValueBool() {
+		// Credentials are only returned on creation, so carry over whatever
+		// is already in state.
+		plan.LicenseKey = state.LicenseKey
+		plan.Password = state.Password
+	} else {
+		plan.LicenseKey = types.StringNull()
+		plan.Password = types.StringNull()
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -263,6 +336,11 @@ func (r *collectorResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *collectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_collector")
+		return
+	}
+
 	// Retrieve values from state
 	var state collectorResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -271,6 +349,15 @@ func (r *collectorResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
 	success, err := r.client.DeleteCollector(ctx, state.ID.ValueString())
 	if err != nil {
@@ -32,6 +32,15 @@ func TestAcc_CollectorResource(t *testing.T) {
 				ResourceName: resourceName,
 				ImportState:  true,
 			},
+			// Test configuring per-operation timeouts
+			{
+				Config: testAccCollectorResourceConfigWithTimeouts(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "timeouts.create", "20m"),
+					resource.TestCheckResourceAttr(resourceName, "timeouts.read", "2m"),
+				),
+			},
 		},
 	})
 }
@@ -44,3 +53,17 @@ resource "armis_collector" "test" {
 }
 `, name)
 }
+
+func testAccCollectorResourceConfigWithTimeouts(name string) string {
+	return fmt.Sprintf(`
+resource "armis_collector" "test" {
+  name            = %q
+  deployment_type = "OVA"
+
+  timeouts {
+    create = "20m"
+    read   = "2m"
+  }
+}
+`, name)
+}
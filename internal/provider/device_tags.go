@@ -0,0 +1,110 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// deviceTagsID derives a stable resource ID from the query and tags, which
+// together identify an armis_device_tags resource.
+func deviceTagsID(asq string, tags []string) string {
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(asq + "\n" + strings.Join(sorted, "\n")))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// deviceTagChanges returns the tags to add to and remove from a device that
+// carries current so that it carries every tag of desired and none of
+// dropped.
+func deviceTagChanges(current, desired, dropped []string) (add, remove []string) {
+	carried := make(map[string]bool, len(current))
+	for _, tag := range current {
+		carried[tag] = true
+	}
+
+	for _, tag := range desired {
+		if !carried[tag] {
+			add = append(add, tag)
+			carried[tag] = true
+		}
+	}
+	for _, tag := range dropped {
+		if carried[tag] {
+			remove = append(remove, tag)
+			delete(carried, tag)
+		}
+	}
+
+	sort.Strings(add)
+	sort.Strings(remove)
+
+	return add, remove
+}
+
+// droppedTags returns the tags of prior missing from desired.
+func droppedTags(prior, desired []string) []string {
+	kept := make(map[string]bool, len(desired))
+	for _, tag := range desired {
+		kept[tag] = true
+	}
+
+	var dropped []string
+	for _, tag := range prior {
+		if !kept[tag] {
+			dropped = append(dropped, tag)
+		}
+	}
+	sort.Strings(dropped)
+
+	return dropped
+}
+
+// addedDeviceTags returns the tags a resource has added to a device once add
+// was added: the tags of prior that are still desired and the tags of add,
+// sorted and without duplicates.
+func addedDeviceTags(prior, desired, add []string) []string {
+	var result []string
+	for _, tag := range prior {
+		if slices.Contains(desired, tag) && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	for _, tag := range add {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// tagsCarriedByAll returns the tags of configured that every device carries,
+// in the order of configured. A tag missing from any device is left out so
+// that it shows up as drift and is applied again.
+func tagsCarriedByAll(deviceTags [][]string, configured []string) []string {
+	result := []string{}
+	for _, tag := range configured {
+		carried := true
+		for _, tags := range deviceTags {
+			if !slices.Contains(tags, tag) {
+				carried = false
+				break
+			}
+		}
+		if carried {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
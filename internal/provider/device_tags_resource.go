@@ -0,0 +1,415 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &deviceTagsResource{}
+	_ resource.ResourceWithConfigure = &deviceTagsResource{}
+)
+
+// deviceTagFields are the device fields needed to apply tags.
+var deviceTagFields = []string{"id", "tags"}
+
+type deviceTagsResource struct {
+	client   *armis.Client
+	readOnly bool
+	cache    *cache.Cache
+}
+
+func DeviceTagsResource() resource.Resource {
+	return &deviceTagsResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *deviceTagsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+	r.cache = data.Cache
+}
+
+// Metadata returns the resource type name.
+func (r *deviceTagsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_tags"
+}
+
+// Schema defines the schema for the device tags resource.
+func (r *deviceTagsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Applies tags to every device matching an ASQ query.
+
+The tags are added to the matching devices on create and update, and removed again on destroy. When the query or the tags change, the tags are removed from devices that no longer match, and tags that are no longer configured are removed. Only the tags this resource added, recorded in ` + "`added_tags`" + `, are ever removed: a device that already carried a tag keeps it. A refresh reports devices that match the query but miss one of the tags as drift, so the next apply tags them.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The identifier of the resource, derived from the query and tags it was created with.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"asq": schema.StringAttribute{
+				Required:    true,
+				Description: "The Armis Standard Query (ASQ) selecting the devices to tag. Must query devices, for example 'in:devices type:PLC site:\"Kansas City Plant\"'.",
+				Validators: []validator.String{
+					verify.ValidASQ("devices"),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The tags every matching device must carry.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"matched_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of devices matching the query when it was last read.",
+			},
+			"added_tags": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.SetType{ElemType: types.StringType},
+				Description: "The tags this resource added, keyed by device ID. Tags a device already carried are not recorded. Only these tags are removed on update and destroy.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// deviceTagsResourceModel maps the resource schema data.
+type deviceTagsResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	ASQ          types.String   `tfsdk:"asq"`
+	Tags         []types.String `tfsdk:"tags"`
+	MatchedCount types.Int64    `tfsdk:"matched_count"`
+	AddedTags    types.Map      `tfsdk:"added_tags"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Create tags the devices matching the query.
+func (r *deviceTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_device_tags")
+		return
+	}
+
+	defer r.cache.InvalidateTags()
+
+	var plan deviceTagsResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tags := typesStringSliceToStrings(plan.Tags)
+	plan.ID = types.StringValue(deviceTagsID(plan.ASQ.ValueString(), tags))
+
+	// Tags added before a failing call are recorded in state, so that the
+	// next apply or destroy removes them.
+	applyDeviceTags(ctx, r.client, &plan, nil, &resp.Diagnostics)
+	if plan.AddedTags.IsUnknown() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the matched count and reports devices missing a tag.
+func (r *deviceTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deviceTagsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Reading devices matching ASQ from Armis", map[string]any{"asq": state.ASQ.ValueString()})
+
+	devices, total, err := searchDevices(ctx, r.client, state.ASQ.ValueString(), deviceTagFields, 0)
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Error searching devices", err)
+		return
+	}
+
+	deviceTags := make([][]string, len(devices))
+	for i, device := range devices {
+		deviceTags[i] = device.Tags
+	}
+	// Tags missing from a device drop out of tags to show up as drift. The
+	// tags this resource added stay recorded in added_tags, so they are still
+	// removed on destroy.
+	carried := tagsCarriedByAll(deviceTags, typesStringSliceToStrings(state.Tags))
+
+	state.Tags = stringsToTypesStringSlice(carried)
+	if state.Tags == nil {
+		state.Tags = []types.String{}
+	}
+	state.MatchedCount = types.Int64Value(int64(total))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update tags the devices matching the new query and untags the devices and
+// tags no longer configured.
+func (r *deviceTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_device_tags")
+		return
+	}
+
+	defer r.cache.InvalidateTags()
+
+	var plan deviceTagsResourceModel
+	var state deviceTagsResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var prior map[string][]string
+	resp.Diagnostics.Append(state.AddedTags.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	// Tags added before a failing call are recorded in state, so that the
+	// next apply or destroy removes them.
+	applyDeviceTags(ctx, r.client, &plan, prior, &resp.Diagnostics)
+	if plan.AddedTags.IsUnknown() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the tags this resource added from the devices.
+func (r *deviceTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_device_tags")
+		return
+	}
+
+	defer r.cache.InvalidateTags()
+
+	var state deviceTagsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var added map[string][]string
+	resp.Diagnostics.Append(state.AddedTags.ElementsAs(ctx, &added, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Removing tags from devices in Armis", map[string]any{
+		"tags":    typesStringSliceToStrings(state.Tags),
+		"devices": len(added),
+	})
+
+	for _, id := range slices.Sorted(maps.Keys(added)) {
+		removeDeviceTags(ctx, r.client, id, added[id], &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+}
+
+// deviceTagger is the part of the Armis client used to apply device tags.
+type deviceTagger interface {
+	deviceSearcher
+	AddDeviceTags(ctx context.Context, id string, tags []string) error
+	RemoveDeviceTags(ctx context.Context, id string, tags []string) error
+}
+
+// applyDeviceTags tags the devices matching the planned query and records the
+// tags it added in plan. prior holds the tags added before, keyed by device
+// ID, on update; devices that no longer match lose those tags, and devices
+// that still match lose the ones no longer configured. Each device is
+// recorded as soon as its tags change, so that when a call fails plan still
+// holds every tag added so far. added_tags is left unknown only when the
+// search fails, before any device changed.
+func applyDeviceTags(ctx context.Context, client deviceTagger, plan *deviceTagsResourceModel, prior map[string][]string, diags *diag.Diagnostics) {
+	asq := plan.ASQ.ValueString()
+	tags := typesStringSliceToStrings(plan.Tags)
+
+	devices, total, err := searchDevices(ctx, client, asq, deviceTagFields, 0)
+	if err != nil {
+		appendAPIError(diags, "Error searching devices", err)
+		return
+	}
+
+	tflog.Info(ctx, "Tagging devices in Armis", map[string]any{
+		"asq":     asq,
+		"tags":    tags,
+		"matched": total,
+	})
+
+	plan.MatchedCount = types.Int64Value(int64(total))
+	added := maps.Clone(prior)
+	if added == nil {
+		added = make(map[string][]string, len(devices))
+	}
+	defer func() {
+		addedTags, d := types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, added)
+		diags.Append(d...)
+		plan.AddedTags = addedTags
+	}()
+
+	matched := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		id := strconv.Itoa(device.ID)
+		matched[id] = true
+
+		add, remove := deviceTagChanges(device.Tags, tags, droppedTags(prior[id], tags))
+		if len(add) > 0 {
+			if err := client.AddDeviceTags(ctx, id, add); err != nil {
+				appendAPIError(diags, fmt.Sprintf("Error adding tags to device %s", id), err)
+				return
+			}
+			// The dropped tags stay recorded until they are removed.
+			added[id] = addedDeviceTags(prior[id], prior[id], add)
+		}
+		if len(remove) > 0 {
+			removeDeviceTags(ctx, client, id, remove, diags)
+			if diags.HasError() {
+				return
+			}
+		}
+
+		if kept := addedDeviceTags(prior[id], tags, add); len(kept) > 0 {
+			added[id] = kept
+		} else {
+			delete(added, id)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(prior)) {
+		if !matched[id] {
+			removeDeviceTags(ctx, client, id, prior[id], diags)
+			if diags.HasError() {
+				return
+			}
+			delete(added, id)
+		}
+	}
+}
+
+// removeDeviceTags removes tags from a device, ignoring devices that no longer
+// exist.
+func removeDeviceTags(ctx context.Context, client deviceTagger, id string, tags []string, diags *diag.Diagnostics) {
+	if len(tags) == 0 {
+		return
+	}
+
+	err := client.RemoveDeviceTags(ctx, id, tags)
+	if err == nil {
+		return
+	}
+
+	var ae *armis.APIError
+	if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+		tflog.Warn(ctx, "Device not found, skipping tag removal", map[string]any{"device_id": id})
+		return
+	}
+
+	appendAPIError(diags, fmt.Sprintf("Error removing tags from device %s", id), err)
+}
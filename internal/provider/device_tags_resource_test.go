@@ -0,0 +1,54 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAcc_DeviceTagsResource tests tagging the devices matching a query and
+// replacing the tag in place.
+func TestAcc_DeviceTagsResource(t *testing.T) {
+	resourceName := "armis_device_tags.test"
+	tag := acctest.RandomWithPrefix("tfacc-tag")
+	updatedTag := acctest.RandomWithPrefix("tfacc-tag")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceTagsResourceConfig(tag),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "tags.*", tag),
+					resource.TestCheckResourceAttrSet(resourceName, "matched_count"),
+					resource.TestCheckResourceAttrSet(resourceName, "added_tags.%"),
+				),
+			},
+			// Test replacing the tag in place
+			{
+				Config: testAccDeviceTagsResourceConfig(updatedTag),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "tags.*", updatedTag),
+				),
+			},
+		},
+	})
+}
+
+func testAccDeviceTagsResourceConfig(tag string) string {
+	return fmt.Sprintf(`
+resource "armis_device_tags" "test" {
+  asq  = "in:devices timeFrame:\"1 Hours\" type:\"Personal Computers\""
+  tags = [%q]
+}
+`, tag)
+}
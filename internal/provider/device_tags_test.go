@@ -0,0 +1,296 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestDeviceTagChanges tests computing the tags to add to and remove from a device.
+func TestDeviceTagChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		current        []string
+		desired        []string
+		dropped        []string
+		expectedAdd    []string
+		expectedRemove []string
+	}{
+		{
+			name:        "untagged device",
+			desired:     []string{"PLC", "OT"},
+			expectedAdd: []string{"OT", "PLC"},
+		},
+		{
+			name:    "already tagged",
+			current: []string{"OT", "PLC", "Critical"},
+			desired: []string{"PLC", "OT"},
+		},
+		{
+			name:           "tag replaced",
+			current:        []string{"OT", "Legacy"},
+			desired:        []string{"OT", "PLC"},
+			dropped:        []string{"Legacy"},
+			expectedAdd:    []string{"PLC"},
+			expectedRemove: []string{"Legacy"},
+		},
+		{
+			name:    "dropped tag not carried",
+			current: []string{"OT"},
+			desired: []string{"OT"},
+			dropped: []string{"Legacy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := deviceTagChanges(tt.current, tt.desired, tt.dropped)
+			if !reflect.DeepEqual(add, tt.expectedAdd) {
+				t.Errorf("Expected add %v, got %v", tt.expectedAdd, add)
+			}
+			if !reflect.DeepEqual(remove, tt.expectedRemove) {
+				t.Errorf("Expected remove %v, got %v", tt.expectedRemove, remove)
+			}
+		})
+	}
+}
+
+// TestDroppedTags tests finding the tags no longer configured.
+func TestDroppedTags(t *testing.T) {
+	t.Parallel()
+
+	got := droppedTags([]string{"PLC", "Legacy", "OT", "Old"}, []string{"OT", "PLC", "New"})
+	expected := []string{"Legacy", "Old"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := droppedTags(nil, []string{"OT"}); got != nil {
+		t.Errorf("Expected no dropped tags on create, got %v", got)
+	}
+}
+
+// TestAddedDeviceTags tests recording the tags the resource added to a device.
+func TestAddedDeviceTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		prior    []string
+		desired  []string
+		add      []string
+		expected []string
+	}{
+		{
+			name:     "tags the device already carried are not recorded",
+			desired:  []string{"PLC", "OT"},
+			add:      []string{"PLC"},
+			expected: []string{"PLC"},
+		},
+		{
+			name:    "device already carried every tag",
+			desired: []string{"PLC", "OT"},
+		},
+		{
+			name:     "tags no longer configured are forgotten",
+			prior:    []string{"Legacy", "OT"},
+			desired:  []string{"OT", "PLC"},
+			add:      []string{"PLC"},
+			expected: []string{"OT", "PLC"},
+		},
+		{
+			name:     "tags added again are recorded once",
+			prior:    []string{"OT"},
+			desired:  []string{"OT"},
+			add:      []string{"OT"},
+			expected: []string{"OT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := addedDeviceTags(tt.prior, tt.desired, tt.add)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestTagsCarriedByAll tests detecting devices that miss a configured tag.
+func TestTagsCarriedByAll(t *testing.T) {
+	t.Parallel()
+
+	configured := []string{"PLC", "OT"}
+
+	tests := []struct {
+		name       string
+		deviceTags [][]string
+		expected   []string
+	}{
+		{
+			name:     "no devices",
+			expected: []string{"PLC", "OT"},
+		},
+		{
+			name:       "all tagged",
+			deviceTags: [][]string{{"OT", "PLC"}, {"PLC", "OT", "Critical"}},
+			expected:   []string{"PLC", "OT"},
+		},
+		{
+			name:       "new device missing a tag",
+			deviceTags: [][]string{{"OT", "PLC"}, {"OT"}},
+			expected:   []string{"OT"},
+		},
+		{
+			name:       "untagged device",
+			deviceTags: [][]string{{"OT", "PLC"}, nil},
+			expected:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tagsCarriedByAll(tt.deviceTags, configured)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestDeviceTagsID tests that the resource ID does not depend on tag order.
+func TestDeviceTagsID(t *testing.T) {
+	t.Parallel()
+
+	a := deviceTagsID("in:devices type:PLC", []string{"PLC", "OT"})
+	b := deviceTagsID("in:devices type:PLC", []string{"OT", "PLC"})
+	if a != b {
+		t.Errorf("Expected the same ID for reordered tags, got %q and %q", a, b)
+	}
+
+	if c := deviceTagsID("in:devices type:HMI", []string{"PLC", "OT"}); c == a {
+		t.Errorf("Expected a different ID for a different query, got %q", c)
+	}
+}
+
+// fakeDeviceTagger serves devices carrying tags and fails every tag change
+// on the device failID.
+type fakeDeviceTagger struct {
+	devices map[int][]string
+	failID  string
+}
+
+func (f *fakeDeviceTagger) SearchDevices(_ context.Context, opts armis.SearchOptions) (*armis.DeviceSearchResults, error) {
+	page := &armis.DeviceSearchResults{Total: len(f.devices)}
+	for id := 1; id <= len(f.devices); id++ {
+		page.Results = append(page.Results, armis.DeviceSettings{ID: id, Tags: f.devices[id]})
+	}
+	page.Count = len(page.Results)
+
+	return page, nil
+}
+
+func (f *fakeDeviceTagger) AddDeviceTags(_ context.Context, id string, tags []string) error {
+	return f.change(id, func(n int) { f.devices[n] = append(f.devices[n], tags...) })
+}
+
+func (f *fakeDeviceTagger) RemoveDeviceTags(_ context.Context, id string, tags []string) error {
+	return f.change(id, func(n int) { f.devices[n] = droppedTags(f.devices[n], tags) })
+}
+
+func (f *fakeDeviceTagger) change(id string, apply func(n int)) error {
+	if id == f.failID {
+		return errors.New("connection reset")
+	}
+
+	n, _ := strconv.Atoi(id)
+	apply(n)
+
+	return nil
+}
+
+// TestApplyDeviceTagsRecordsPartialChanges tests that the tags added before a
+// failing call are recorded.
+func TestApplyDeviceTagsRecordsPartialChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		devices  map[int][]string
+		prior    map[string][]string
+		failID   string
+		expected map[string][]string
+	}{
+		{
+			name:     "create fails on the second device",
+			devices:  map[int][]string{1: nil, 2: nil, 3: nil},
+			failID:   "2",
+			expected: map[string][]string{"1": {"PLC"}},
+		},
+		{
+			name:     "create fails on the last device",
+			devices:  map[int][]string{1: {"PLC"}, 2: nil, 3: nil},
+			failID:   "3",
+			expected: map[string][]string{"2": {"PLC"}},
+		},
+		{
+			name:     "update keeps the prior tags of the failing device",
+			devices:  map[int][]string{1: {"Legacy"}, 2: {"Legacy"}},
+			prior:    map[string][]string{"1": {"Legacy"}, "2": {"Legacy"}, "9": {"Legacy"}},
+			failID:   "2",
+			expected: map[string][]string{"1": {"PLC"}, "2": {"Legacy"}, "9": {"Legacy"}},
+		},
+		{
+			name:     "update fails untagging a device that no longer matches",
+			devices:  map[int][]string{1: nil},
+			prior:    map[string][]string{"1": {"PLC"}, "8": {"PLC"}, "9": {"PLC"}},
+			failID:   "9",
+			expected: map[string][]string{"1": {"PLC"}, "9": {"PLC"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeDeviceTagger{devices: tt.devices, failID: tt.failID}
+			plan := deviceTagsResourceModel{
+				ASQ:       types.StringValue("in:devices type:PLC"),
+				Tags:      []types.String{types.StringValue("PLC")},
+				AddedTags: types.MapUnknown(types.SetType{ElemType: types.StringType}),
+			}
+
+			var diags diag.Diagnostics
+			applyDeviceTags(context.Background(), client, &plan, tt.prior, &diags)
+			if !diags.HasError() {
+				t.Fatal("Expected an error")
+			}
+
+			var got map[string][]string
+			if d := plan.AddedTags.ElementsAs(context.Background(), &got, false); d.HasError() {
+				t.Fatalf("Expected known added tags, got %s: %v", plan.AddedTags, d)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected added tags %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultDeviceLimit is the number of devices returned when limit is not set.
+const defaultDeviceLimit = 1000
+
+// deviceFields are the device fields read by the devices data source.
+var deviceFields = []string{"id", "name", "ipAddress", "macAddress", "type", "site", "tags", "riskLevel"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &devicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &devicesDataSource{}
+)
+
+// Configure adds the provider configured client to the data source.
+func (d *devicesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+// DevicesDataSource is a helper function to simplify the provider implementation.
+func DevicesDataSource() datasource.DataSource {
+	return &devicesDataSource{}
+}
+
+// devicesDataSource is the data source implementation.
+type devicesDataSource struct {
+	client *armis.Client
+}
+
+// Metadata returns the data source type name.
+func (d *devicesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devices"
+}
+
+// Schema defines the schema for the devices data source.
+func (d *devicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the Armis devices matching an ASQ query. Results are paged through until limit devices are read.",
+		Attributes: map[string]schema.Attribute{
+			"asq": schema.StringAttribute{
+				Description: "The Armis Standard Query (ASQ) selecting the devices, with the same syntax as armis_report.asq. Example: 'in:devices type:PLC timeFrame:\"7 Days\"'",
+				Required:    true,
+				Validators: []validator.String{
+					verify.ValidASQ("devices"),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of devices to return. Defaults to %d.", defaultDeviceLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total": schema.Int64Attribute{
+				Description: "The total number of devices matching the query, which may exceed the number returned.",
+				Computed:    true,
+			},
+			"devices": schema.ListNestedAttribute{
+				Description: "A computed list of devices. Each object in the list contains detailed information about a device.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "A unique identifier for the device.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the device.",
+							Computed:    true,
+						},
+						"ip": schema.StringAttribute{
+							Description: "The IP address of the device.",
+							Computed:    true,
+						},
+						"mac": schema.StringAttribute{
+							Description: "The MAC address of the device.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the device, such as PLC or Personal Computers.",
+							Computed:    true,
+						},
+						"site": schema.StringAttribute{
+							Description: "The name of the site of the device.",
+							Computed:    true,
+						},
+						"tags": schema.ListAttribute{
+							Description: "The tags of the device.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"risk": schema.Int64Attribute{
+							Description: "The risk level of the device.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// devicesDataSourceModel maps the data source schema data.
+type devicesDataSourceModel struct {
+	ASQ     types.String  `tfsdk:"asq"`
+	Limit   types.Int64   `tfsdk:"limit"`
+	Total   types.Int64   `tfsdk:"total"`
+	Devices []deviceModel `tfsdk:"devices"`
+}
+
+// deviceModel maps the device schema data.
+type deviceModel struct {
+	ID   types.String   `tfsdk:"id"`
+	Name types.String   `tfsdk:"name"`
+	IP   types.String   `tfsdk:"ip"`
+	MAC  types.String   `tfsdk:"mac"`
+	Type types.String   `tfsdk:"type"`
+	Site types.String   `tfsdk:"site"`
+	Tags []types.String `tfsdk:"tags"`
+	Risk types.Int64    `tfsdk:"risk"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *devicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state devicesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultDeviceLimit
+	if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	// Page through the matching devices
+	apiDevices, total, err := searchDevices(ctx, d.client, state.ASQ.ValueString(), deviceFields, limit)
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Devices", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetched devices from Armis API", map[string]any{
+		"asq":          state.ASQ.ValueString(),
+		"limit":        limit,
+		"total_count":  total,
+		"return_count": len(apiDevices),
+	})
+
+	// Map response body to model
+	devices := make([]deviceModel, 0, len(apiDevices))
+	for _, device := range apiDevices {
+		tags := make([]types.String, 0, len(device.Tags))
+		for _, tag := range device.Tags {
+			tags = append(tags, types.StringValue(tag))
+		}
+
+		devices = append(devices, deviceModel{
+			ID:   types.StringValue(strconv.Itoa(device.ID)),
+			Name: types.StringValue(device.Name),
+			IP:   types.StringValue(device.IPAddress),
+			MAC:  types.StringValue(device.MacAddress),
+			Type: types.StringValue(device.Type),
+			Site: types.StringValue(device.Site.Name),
+			Tags: tags,
+			Risk: types.Int64Value(int64(device.RiskLevel)),
+		})
+	}
+
+	// Save data into Terraform state
+	state.Total = types.Int64Value(int64(total))
+	state.Devices = devices
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
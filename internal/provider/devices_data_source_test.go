@@ -0,0 +1,38 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DevicesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDevicesDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.armis_devices.test", "total"),
+					resource.TestCheckResourceAttr("data.armis_devices.test", "devices.#", "5"),
+					resource.TestCheckResourceAttrSet("data.armis_devices.test", "devices.0.id"),
+					resource.TestCheckResourceAttrSet("data.armis_devices.test", "devices.0.type"),
+					resource.TestCheckResourceAttrSet("data.armis_devices.test", "devices.0.risk"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDevicesDataSourceConfig() string {
+	return `
+data "armis_devices" "test" {
+  asq   = "in:devices timeFrame:\"7 Days\""
+  limit = 5
+}
+`
+}
@@ -29,17 +29,17 @@ func (d *listsDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
 
 // ListsDataSource is a helper function to simplify the provider implementation.
@@ -146,10 +146,7 @@ func (d *listsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Fetch all lists
 	apiLists, err := d.client.GetLists(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Armis Lists",
-			err.Error(),
-		)
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Lists", err)
 		return
 	}
 
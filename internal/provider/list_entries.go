@@ -0,0 +1,183 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"net"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// List types that determine what the entries of an Armis list hold.
+const (
+	listTypeIPAddress  = "IP_ADDRESS"
+	listTypeMACAddress = "MAC_ADDRESS"
+	listTypeDomain     = "DOMAIN"
+	listTypeDeviceID   = "DEVICE_ID"
+)
+
+// listTypes are the list types accepted by armis_list.
+var listTypes = []string{listTypeIPAddress, listTypeMACAddress, listTypeDomain, listTypeDeviceID}
+
+var domainPattern = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}\.?$`)
+
+// listEntryProblem describes why entry is not valid for a list of the given
+// type, or returns an empty string when it is. Entries of unknown list types
+// are passed to the API as they are.
+func listEntryProblem(listType, entry string) string {
+	switch listType {
+	case listTypeIPAddress:
+		if _, err := netip.ParseAddr(entry); err == nil {
+			return ""
+		}
+		if _, err := netip.ParsePrefix(entry); err == nil {
+			return ""
+		}
+		return "must be an IPv4 or IPv6 address or CIDR block"
+	case listTypeMACAddress:
+		if _, err := net.ParseMAC(entry); err != nil {
+			return "must be a MAC address such as 00:1a:2b:3c:4d:5e"
+		}
+	case listTypeDomain:
+		if !domainPattern.MatchString(strings.ToLower(entry)) {
+			return "must be a domain name such as example.com or *.example.com"
+		}
+	case listTypeDeviceID:
+		if id, err := strconv.ParseUint(entry, 10, 64); err != nil || id == 0 {
+			return "must be a numeric Armis device ID"
+		}
+	}
+
+	return ""
+}
+
+// normalizeListEntry returns the form used to compare entries, so that
+// 00:1A:2B:3C:4D:5E and 00-1a-2b-3c-4d-5e are the same MAC address.
+func normalizeListEntry(listType, entry string) string {
+	entry = strings.TrimSpace(entry)
+
+	switch listType {
+	case listTypeIPAddress:
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			return addr.String()
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			return prefix.Masked().String()
+		}
+	case listTypeMACAddress:
+		if mac, err := net.ParseMAC(entry); err == nil {
+			return mac.String()
+		}
+	case listTypeDomain:
+		return strings.TrimSuffix(strings.ToLower(entry), ".")
+	}
+
+	return entry
+}
+
+// diffListEntries returns the entries of desired missing from current and the
+// entries of current missing from desired. Both results are sorted and use
+// the spelling of the slice they come from.
+func diffListEntries(listType string, current, desired []string) (add, remove []string) {
+	currentSet := make(map[string]bool, len(current))
+	for _, entry := range current {
+		currentSet[normalizeListEntry(listType, entry)] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, entry := range desired {
+		desiredSet[normalizeListEntry(listType, entry)] = true
+	}
+
+	for _, entry := range desired {
+		key := normalizeListEntry(listType, entry)
+		if !currentSet[key] {
+			add = append(add, entry)
+			currentSet[key] = true
+		}
+	}
+	for _, entry := range current {
+		key := normalizeListEntry(listType, entry)
+		if !desiredSet[key] {
+			remove = append(remove, entry)
+			desiredSet[key] = true
+		}
+	}
+
+	sort.Strings(add)
+	sort.Strings(remove)
+
+	return add, remove
+}
+
+// intersectListEntries returns the entries of current that match one of
+// entries, sorted and spelled the way current spells them.
+func intersectListEntries(listType string, current, entries []string) []string {
+	wanted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		wanted[normalizeListEntry(listType, entry)] = true
+	}
+
+	var result []string
+	for _, entry := range current {
+		key := normalizeListEntry(listType, entry)
+		if wanted[key] {
+			result = append(result, entry)
+			delete(wanted, key)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// mergeAddedListEntries returns the entries a resource has added to a list
+// once add was added: the entries of prior that are still desired and the
+// entries of add, sorted and without duplicates.
+func mergeAddedListEntries(listType string, prior, desired, add []string) []string {
+	result := []string{}
+	seen := make(map[string]bool, len(prior)+len(add))
+	for _, entry := range append(intersectListEntries(listType, prior, desired), add...) {
+		key := normalizeListEntry(listType, entry)
+		if !seen[key] {
+			result = append(result, entry)
+			seen[key] = true
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// reconcileListEntries returns the entries of current to record in state.
+// Entries that match one of configured keep its spelling, so that the API
+// normalizing an address does not show up as drift. When all is false only
+// the configured entries are considered.
+func reconcileListEntries(listType string, current, configured []string, all bool) []string {
+	spelling := make(map[string]string, len(configured))
+	for _, entry := range configured {
+		spelling[normalizeListEntry(listType, entry)] = entry
+	}
+
+	result := []string{}
+	seen := make(map[string]bool, len(current))
+	for _, entry := range current {
+		key := normalizeListEntry(listType, entry)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if configuredEntry, ok := spelling[key]; ok {
+			result = append(result, configuredEntry)
+		} else if all {
+			result = append(result, entry)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
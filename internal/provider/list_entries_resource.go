@@ -0,0 +1,438 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &listEntriesResource{}
+	_ resource.ResourceWithConfigure   = &listEntriesResource{}
+	_ resource.ResourceWithImportState = &listEntriesResource{}
+)
+
+type listEntriesResource struct {
+	client   *armis.Client
+	readOnly bool
+}
+
+func ListEntriesResource() resource.Resource {
+	return &listEntriesResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *listEntriesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+// Metadata returns the resource type name.
+func (r *listEntriesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list_entries"
+}
+
+// Schema defines the schema for the list entries resource.
+func (r *listEntriesResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Manages the entries of an Armis list.
+
+Entries must match the ` + "`list_type`" + ` of the list: IP addresses or CIDR blocks, MAC addresses, domain names or numeric device IDs. Entries are compared in their normalized form, so ` + "`00-1A-2B-3C-4D-5E`" + ` and ` + "`00:1a:2b:3c:4d:5e`" + ` are the same MAC address.
+
+By default the resource is additive: it adds the configured entries and removes only the entries it added, recorded in ` + "`added_entries`" + `, leaving entries that were already on the list or added by other means alone. Set ` + "`authoritative`" + ` to make the configured entries the complete contents of the list.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of the list, same as list_id.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"list_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the list whose entries are managed.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"entries": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The entries of the list. May be empty when authoritative is true, which removes every entry.",
+			},
+			"authoritative": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether entries is the complete contents of the list. Entries not in the configuration are removed and reported as drift. Defaults to false.",
+			},
+			"added_entries": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The entries this resource added because they were not on the list yet. In additive mode only these are removed when they leave entries or the resource is destroyed.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// listEntriesResourceModel maps the resource schema data.
+type listEntriesResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	ListID        types.String   `tfsdk:"list_id"`
+	Entries       []types.String `tfsdk:"entries"`
+	Authoritative types.Bool     `tfsdk:"authoritative"`
+	AddedEntries  types.Set      `tfsdk:"added_entries"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Create adds the configured entries to the list.
+func (r *listEntriesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_list_entries")
+		return
+	}
+
+	var plan listEntriesResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.apply(ctx, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.ListID
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the entries from the list.
+func (r *listEntriesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state listEntriesResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Imported resources only know their ID and take the whole list until
+	// the configuration is applied.
+	imported := state.ListID.IsNull()
+	if imported {
+		state.ListID = state.ID
+	}
+	listID := state.ListID.ValueString()
+
+	tflog.Info(ctx, "Reading list entries from Armis", map[string]any{"list_id": listID})
+
+	list, err := r.client.GetListByID(ctx, listID)
+	var current []string
+	if err == nil && list != nil {
+		current, err = r.client.GetListItems(ctx, listID)
+	}
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "List not found, removing list entries from state", map[string]any{
+				"list_id": listID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error reading entries of list %s", listID), err)
+		return
+	}
+	if list == nil {
+		tflog.Warn(ctx, "List not found, removing list entries from state", map[string]any{
+			"list_id": listID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if state.Authoritative.IsNull() {
+		state.Authoritative = types.BoolValue(false)
+	}
+
+	var added []string
+	resp.Diagnostics.Append(state.AddedEntries.ElementsAs(ctx, &added, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	all := state.Authoritative.ValueBool() || imported
+	entries := reconcileListEntries(list.ListType, current, typesStringSliceToStrings(state.Entries), all)
+
+	state.ID = state.ListID
+	state.Entries = make([]types.String, len(entries))
+	for i, entry := range entries {
+		state.Entries[i] = types.StringValue(entry)
+	}
+
+	// Entries removed by other means are no longer ours to remove.
+	state.AddedEntries, diags = types.SetValueFrom(ctx, types.StringType, reconcileListEntries(list.ListType, current, added, false))
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update brings the entries of the list in line with the plan.
+func (r *listEntriesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_list_entries")
+		return
+	}
+
+	var plan listEntriesResourceModel
+	var state listEntriesResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var priorAdded []string
+	resp.Diagnostics.Append(state.AddedEntries.ElementsAs(ctx, &priorAdded, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, priorAdded, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the managed entries from the list.
+func (r *listEntriesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_list_entries")
+		return
+	}
+
+	var state listEntriesResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	listID := state.ListID.ValueString()
+
+	tflog.Info(ctx, "Removing list entries from Armis", map[string]any{"list_id": listID})
+
+	list, err := r.client.GetListByID(ctx, listID)
+	var current []string
+	if err == nil && list != nil {
+		current, err = r.client.GetListItems(ctx, listID)
+	}
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "List already deleted", map[string]any{"list_id": listID})
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error reading entries of list %s", listID), err)
+		return
+	}
+	if list == nil {
+		tflog.Warn(ctx, "List already deleted", map[string]any{"list_id": listID})
+		return
+	}
+
+	// In additive mode only the entries this resource added are removed.
+	managed := typesStringSliceToStrings(state.Entries)
+	if !state.Authoritative.ValueBool() {
+		managed = nil
+		resp.Diagnostics.Append(state.AddedEntries.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	remove := intersectListEntries(list.ListType, current, managed)
+	if len(remove) == 0 {
+		return
+	}
+
+	if err := r.client.RemoveListItems(ctx, listID, remove); err != nil {
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error removing entries from list %s", listID), err)
+		return
+	}
+
+	tflog.Info(ctx, "List entries removed successfully", map[string]any{
+		"list_id": listID,
+		"removed": len(remove),
+	})
+}
+
+// ImportState imports the entries of an existing list by its ID. Imported
+// entries are additive; set authoritative in the configuration to manage the
+// whole list.
+func (r *listEntriesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// apply validates the planned entries against the list type, adds and
+// removes entries so that the list matches the plan and records the entries
+// this resource added in plan. priorAdded holds the entries added before, on
+// update; in additive mode only those are ever removed.
+func (r *listEntriesResource) apply(ctx context.Context, plan *listEntriesResourceModel, priorAdded []string, diags *diag.Diagnostics) {
+	listID := plan.ListID.ValueString()
+
+	list, err := r.client.GetListByID(ctx, listID)
+	if err != nil {
+		appendAPIError(diags, fmt.Sprintf("Error reading list %s", listID), err)
+		return
+	}
+	if list == nil {
+		diags.AddAttributeError(
+			path.Root("list_id"),
+			"Armis List Not Found",
+			fmt.Sprintf("List %s does not exist.", listID),
+		)
+		return
+	}
+
+	desired := typesStringSliceToStrings(plan.Entries)
+	for _, entry := range desired {
+		if problem := listEntryProblem(list.ListType, entry); problem != "" {
+			diags.AddAttributeError(
+				path.Root("entries"),
+				"Invalid List Entry",
+				fmt.Sprintf("Entry %q of %s list %s %s.", entry, list.ListType, listID, problem),
+			)
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+
+	current, err := r.client.GetListItems(ctx, listID)
+	if err != nil {
+		appendAPIError(diags, fmt.Sprintf("Error reading entries of list %s", listID), err)
+		return
+	}
+
+	add, remove := diffListEntries(list.ListType, current, desired)
+	if !plan.Authoritative.ValueBool() {
+		// Only remove entries this resource added before and no longer
+		// configures.
+		_, dropped := diffListEntries(list.ListType, priorAdded, desired)
+		remove = intersectListEntries(list.ListType, current, dropped)
+	}
+
+	tflog.Info(ctx, "Updating list entries in Armis", map[string]any{
+		"list_id":       listID,
+		"authoritative": plan.Authoritative.ValueBool(),
+		"added":         len(add),
+		"removed":       len(remove),
+	})
+
+	if len(add) > 0 {
+		if err := r.client.AddListItems(ctx, listID, add); err != nil {
+			appendAPIError(diags, fmt.Sprintf("Error adding entries to list %s", listID), err)
+			return
+		}
+	}
+	if len(remove) > 0 {
+		if err := r.client.RemoveListItems(ctx, listID, remove); err != nil {
+			appendAPIError(diags, fmt.Sprintf("Error removing entries from list %s", listID), err)
+			return
+		}
+	}
+
+	added, d := types.SetValueFrom(ctx, types.StringType, mergeAddedListEntries(list.ListType, priorAdded, desired, add))
+	diags.Append(d...)
+	plan.AddedEntries = added
+}
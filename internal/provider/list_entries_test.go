@@ -0,0 +1,221 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestListEntryProblem tests the validation of entries against the list type.
+func TestListEntryProblem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		listType string
+		entry    string
+		valid    bool
+	}{
+		{name: "ipv4 address", listType: listTypeIPAddress, entry: "10.0.0.1", valid: true},
+		{name: "ipv6 address", listType: listTypeIPAddress, entry: "2001:db8::1", valid: true},
+		{name: "cidr block", listType: listTypeIPAddress, entry: "10.0.0.0/8", valid: true},
+		{name: "hostname as ip", listType: listTypeIPAddress, entry: "example.com", valid: false},
+		{name: "colon mac", listType: listTypeMACAddress, entry: "00:1a:2b:3c:4d:5e", valid: true},
+		{name: "dash mac", listType: listTypeMACAddress, entry: "00-1A-2B-3C-4D-5E", valid: true},
+		{name: "short mac", listType: listTypeMACAddress, entry: "00:1a:2b", valid: false},
+		{name: "domain", listType: listTypeDomain, entry: "Example.com", valid: true},
+		{name: "wildcard domain", listType: listTypeDomain, entry: "*.example.com", valid: true},
+		{name: "ip as domain", listType: listTypeDomain, entry: "10.0.0.1", valid: false},
+		{name: "device id", listType: listTypeDeviceID, entry: "1234", valid: true},
+		{name: "zero device id", listType: listTypeDeviceID, entry: "0", valid: false},
+		{name: "named device", listType: listTypeDeviceID, entry: "plc-01", valid: false},
+		{name: "unknown list type", listType: "OTHER", entry: "anything", valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			problem := listEntryProblem(tt.listType, tt.entry)
+			if tt.valid && problem != "" {
+				t.Errorf("Expected %q to be valid, got: %s", tt.entry, problem)
+			}
+			if !tt.valid && problem == "" {
+				t.Errorf("Expected %q to be invalid", tt.entry)
+			}
+		})
+	}
+}
+
+// TestDiffListEntries tests computing the entries to add and remove.
+func TestDiffListEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		listType       string
+		current        []string
+		desired        []string
+		expectedAdd    []string
+		expectedRemove []string
+	}{
+		{
+			name:        "empty list",
+			listType:    listTypeIPAddress,
+			desired:     []string{"10.0.0.2", "10.0.0.1"},
+			expectedAdd: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:           "add and remove",
+			listType:       listTypeIPAddress,
+			current:        []string{"10.0.0.1", "10.0.0.3"},
+			desired:        []string{"10.0.0.1", "10.0.0.2"},
+			expectedAdd:    []string{"10.0.0.2"},
+			expectedRemove: []string{"10.0.0.3"},
+		},
+		{
+			name:     "mac spelling",
+			listType: listTypeMACAddress,
+			current:  []string{"00:1a:2b:3c:4d:5e"},
+			desired:  []string{"00-1A-2B-3C-4D-5E"},
+		},
+		{
+			name:     "unmasked cidr",
+			listType: listTypeIPAddress,
+			current:  []string{"10.0.0.0/8"},
+			desired:  []string{"10.1.2.3/8"},
+		},
+		{
+			name:     "domain case and trailing dot",
+			listType: listTypeDomain,
+			current:  []string{"example.com"},
+			desired:  []string{"Example.COM."},
+		},
+		{
+			name:        "duplicates",
+			listType:    listTypeIPAddress,
+			desired:     []string{"10.0.0.1", "10.0.0.1"},
+			expectedAdd: []string{"10.0.0.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := diffListEntries(tt.listType, tt.current, tt.desired)
+			if !reflect.DeepEqual(add, tt.expectedAdd) {
+				t.Errorf("Expected add %v, got %v", tt.expectedAdd, add)
+			}
+			if !reflect.DeepEqual(remove, tt.expectedRemove) {
+				t.Errorf("Expected remove %v, got %v", tt.expectedRemove, remove)
+			}
+		})
+	}
+}
+
+// TestIntersectListEntries tests selecting the current entries that are managed.
+func TestIntersectListEntries(t *testing.T) {
+	t.Parallel()
+
+	current := []string{"00:1a:2b:3c:4d:5e", "00:1a:2b:3c:4d:5f", "00:1a:2b:3c:4d:60"}
+	entries := []string{"00-1A-2B-3C-4D-5F", "00:1a:2b:3c:4d:5e", "00:1a:2b:3c:4d:61"}
+
+	got := intersectListEntries(listTypeMACAddress, current, entries)
+	expected := []string{"00:1a:2b:3c:4d:5e", "00:1a:2b:3c:4d:5f"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+// TestMergeAddedListEntries tests the entries recorded as added by the resource.
+func TestMergeAddedListEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		prior    []string
+		desired  []string
+		add      []string
+		expected []string
+	}{
+		{
+			name:     "create keeps pre-existing entries out",
+			desired:  []string{"10.0.0.1", "10.0.0.2"},
+			add:      []string{"10.0.0.2"},
+			expected: []string{"10.0.0.2"},
+		},
+		{
+			name:     "update keeps entries still configured",
+			prior:    []string{"10.0.0.2", "10.0.0.3"},
+			desired:  []string{"10.0.0.1", "10.0.0.2", "10.0.0.4"},
+			add:      []string{"10.0.0.4"},
+			expected: []string{"10.0.0.2", "10.0.0.4"},
+		},
+		{
+			name:     "entries added again are recorded once",
+			prior:    []string{"10.0.0.2"},
+			desired:  []string{"10.0.0.2"},
+			add:      []string{"10.0.0.2"},
+			expected: []string{"10.0.0.2"},
+		},
+		{
+			name:     "nothing added",
+			desired:  []string{"10.0.0.1"},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mergeAddedListEntries(listTypeIPAddress, tt.prior, tt.desired, tt.add)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestReconcileListEntries tests the entries recorded in state after a read.
+func TestReconcileListEntries(t *testing.T) {
+	t.Parallel()
+
+	current := []string{"10.0.0.1", "10.0.0.0/8", "192.168.1.1"}
+	configured := []string{"10.1.2.3/8", "10.0.0.1", "10.0.0.2"}
+
+	tests := []struct {
+		name     string
+		all      bool
+		expected []string
+	}{
+		{
+			name:     "additive",
+			all:      false,
+			expected: []string{"10.0.0.1", "10.1.2.3/8"},
+		},
+		{
+			name:     "authoritative",
+			all:      true,
+			expected: []string{"10.0.0.1", "10.1.2.3/8", "192.168.1.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := reconcileListEntries(listTypeIPAddress, current, configured, tt.all)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if got := reconcileListEntries(listTypeIPAddress, nil, configured, true); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty non-nil slice for an empty list, got %#v", got)
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &listResource{}
+	_ resource.ResourceWithConfigure   = &listResource{}
+	_ resource.ResourceWithImportState = &listResource{}
+)
+
+type listResource struct {
+	client   *armis.Client
+	readOnly bool
+}
+
+func ListResource() resource.Resource {
+	return &listResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *listResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+// Metadata returns the resource type name.
+func (r *listResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list"
+}
+
+// Schema defines the schema for the list resource.
+func (r *listResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides an Armis list resource.
+
+Lists hold IP addresses, MAC addresses, domains or device IDs that policies refer to, for example as allow or deny lists. The entries of a list are managed with the ` + "`armis_list_entries`" + ` resource.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The unique identifier for the list.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the list.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "The description of the list.",
+			},
+			"list_type": schema.StringAttribute{
+				Required:    true,
+				Description: "The type of the entries of the list. Valid options include 'IP_ADDRESS', 'MAC_ADDRESS', 'DOMAIN', and 'DEVICE_ID'. Changing it replaces the list.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(listTypes...),
+				},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"created_by": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The user who created the list.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"creation_time": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation time of the list.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"last_updated_by": schema.StringAttribute{
+				Computed:    true,
+				Description: "The user who last updated the list.",
+			},
+			"last_update_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "Last update time of the list.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// listResourceModel maps the resource schema data.
+type listResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Description    types.String   `tfsdk:"description"`
+	ListType       types.String   `tfsdk:"list_type"`
+	CreatedBy      types.String   `tfsdk:"created_by"`
+	CreationTime   types.String   `tfsdk:"creation_time"`
+	LastUpdatedBy  types.String   `tfsdk:"last_updated_by"`
+	LastUpdateTime types.String   `tfsdk:"last_update_time"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *listResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_list")
+		return
+	}
+
+	var plan listResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Creating list in Armis", map[string]any{"list_name": plan.Name.ValueString()})
+
+	list, err := r.client.CreateList(ctx, buildArmisList(plan))
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error creating list %q", plan.Name.ValueString()), err)
+		return
+	}
+	if list == nil || list.ListID == 0 {
+		resp.Diagnostics.AddError(
+			"Error Creating Armis List",
+			fmt.Sprintf("List %q was created but the API did not return its ID", plan.Name.ValueString()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "List created successfully", map[string]any{
+		"list_id":   list.ListID,
+		"list_name": list.ListName,
+	})
+
+	plan = mapListToModel(list, plan)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read reads the resource state from the API.
+func (r *listResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state listResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Reading list from Armis", map[string]any{"list_id": state.ID.ValueString()})
+
+	list, err := r.client.GetListByID(ctx, state.ID.ValueString())
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "List not found, removing from state", map[string]any{
+				"list_id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error reading list %s", state.ID.ValueString()), err)
+		return
+	}
+
+	if list == nil {
+		tflog.Warn(ctx, "List not found, removing from state", map[string]any{
+			"list_id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state = mapListToModel(list, state)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates an existing list in Armis.
+func (r *listResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_list")
+		return
+	}
+
+	var plan listResourceModel
+	var state listResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	listID := state.ID.ValueString()
+
+	tflog.Info(ctx, "Updating list in Armis", map[string]any{
+		"list_id":   listID,
+		"list_name": plan.Name.ValueString(),
+	})
+
+	list, err := r.client.UpdateList(ctx, listID, buildArmisList(plan))
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error updating list %s", listID), err)
+		return
+	}
+	if list == nil {
+		resp.Diagnostics.AddError(
+			"Error Fetching Updated List",
+			fmt.Sprintf("List %s was updated but the API did not return it", listID),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "List updated successfully", map[string]any{
+		"list_id":   listID,
+		"list_name": list.ListName,
+	})
+
+	plan.ID = state.ID
+	plan = mapListToModel(list, plan)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource.
+func (r *listResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_list")
+		return
+	}
+
+	var state listResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Deleting list from Armis", map[string]any{"list_id": state.ID.ValueString()})
+
+	success, err := r.client.DeleteList(ctx, state.ID.ValueString())
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "List already deleted", map[string]any{"list_id": state.ID.ValueString()})
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error deleting list %s", state.ID.ValueString()), err)
+		return
+	}
+
+	if !success {
+		resp.Diagnostics.AddError(
+			"Error Deleting Armis List",
+			"Could not delete list: operation returned unsuccessful status",
+		)
+		return
+	}
+
+	tflog.Info(ctx, "List deleted successfully", map[string]any{"list_id": state.ID.ValueString()})
+}
+
+// ImportState imports an existing list into Terraform state.
+func (r *listResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// buildArmisList converts the Terraform model to SDK list settings.
+func buildArmisList(plan listResourceModel) armis.ListSettings {
+	return armis.ListSettings{
+		ListName:    plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		ListType:    plan.ListType.ValueString(),
+	}
+}
+
+// mapListToModel overlays the list returned by the API on prior.
+func mapListToModel(list *armis.ListSettings, prior listResourceModel) listResourceModel {
+	model := prior
+	model.ID = types.StringValue(strconv.Itoa(list.ListID))
+	model.Name = types.StringValue(list.ListName)
+	model.Description = optionalStringValue(prior.Description, list.Description)
+	model.ListType = types.StringValue(list.ListType)
+	model.CreatedBy = types.StringValue(list.CreatedBy)
+	model.CreationTime = types.StringValue(list.CreationTime)
+	model.LastUpdatedBy = types.StringValue(list.LastUpdatedBy)
+	model.LastUpdateTime = types.StringValue(list.LastUpdateTime)
+
+	return model
+}
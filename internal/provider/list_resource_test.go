@@ -0,0 +1,115 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAcc_ListResource tests creating, updating and importing a list.
+func TestAcc_ListResource(t *testing.T) {
+	resourceName := "armis_list.test"
+	rName := strings.ToLower(acctest.RandomWithPrefix("tfacc-list"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccListResourceConfig(rName, "Managed by Terraform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "list_type", "IP_ADDRESS"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Managed by Terraform"),
+				),
+			},
+			// Test updating the description in place
+			{
+				Config: testAccListResourceConfig(rName, "Updated by Terraform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Updated by Terraform"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAcc_ListEntriesResource tests managing the entries of a list additively
+// and authoritatively.
+func TestAcc_ListEntriesResource(t *testing.T) {
+	resourceName := "armis_list_entries.test"
+	rName := strings.ToLower(acctest.RandomWithPrefix("tfacc-list"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccListEntriesResourceConfig(rName, `["10.0.0.1", "10.1.0.0/16"]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "list_id", "armis_list.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "authoritative", "false"),
+					resource.TestCheckResourceAttr(resourceName, "entries.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "entries.*", "10.0.0.1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "entries.*", "10.1.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "added_entries.#", "2"),
+				),
+			},
+			// Test removing an entry and switching to authoritative
+			{
+				Config: testAccListEntriesResourceConfig(rName, `["10.0.0.1"]`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "authoritative", "true"),
+					resource.TestCheckResourceAttr(resourceName, "entries.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "entries.*", "10.0.0.1"),
+					resource.TestCheckResourceAttr(resourceName, "added_entries.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"authoritative", "added_entries"},
+			},
+		},
+	})
+}
+
+func testAccListResourceConfig(name, description string) string {
+	return fmt.Sprintf(`
+resource "armis_list" "test" {
+  name        = %q
+  description = %q
+  list_type   = "IP_ADDRESS"
+}
+`, name, description)
+}
+
+func testAccListEntriesResourceConfig(name, entries string, authoritative bool) string {
+	return fmt.Sprintf(`
+resource "armis_list" "test" {
+  name      = %q
+  list_type = "IP_ADDRESS"
+}
+
+resource "armis_list_entries" "test" {
+  list_id       = armis_list.test.id
+  entries       = %s
+  authoritative = %t
+}
+`, name, entries, authoritative)
+}
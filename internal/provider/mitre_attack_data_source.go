@@ -0,0 +1,252 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultMitreMatrix is the matrix searched when matrix is not set.
+const defaultMitreMatrix = "Enterprise"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &mitreAttackDataSource{}
+)
+
+// MitreAttackDataSource is a helper function to simplify the provider implementation.
+func MitreAttackDataSource() datasource.DataSource {
+	return &mitreAttackDataSource{}
+}
+
+// mitreAttackDataSource is the data source implementation. It reads the
+// embedded ATT&CK catalog and does not call the Armis API.
+type mitreAttackDataSource struct{}
+
+// Metadata returns the data source type name.
+func (d *mitreAttackDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mitre_attack"
+}
+
+// Schema defines the schema for the MITRE ATT&CK data source.
+func (d *mitreAttackDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up MITRE ATT&CK tactics and techniques by name or ID in the catalog embedded in the provider, " +
+			"and returns the labels to use in armis_policy.mitre_attack_labels. No Armis API calls are made.",
+		Attributes: map[string]schema.Attribute{
+			"matrix": schema.StringAttribute{
+				Description: "The ATT&CK matrix to search. Valid options include 'Enterprise', 'Mobile', and 'ICS'. Defaults to 'Enterprise'.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("Enterprise", "Mobile", "ICS"),
+				},
+			},
+			"tactic": schema.StringAttribute{
+				Description: "The name or ID of a tactic, such as 'Collection' or 'TA0009'. " +
+					"When technique is not set, every technique of the tactic is returned.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AtLeastOneOf(path.MatchRoot("technique")),
+				},
+			},
+			"technique": schema.StringAttribute{
+				Description: "The name or ID of a technique, such as 'Input Capture' or 'T1056'. " +
+					"When tactic is not set, a label is returned for each tactic the technique belongs to.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"sub_technique": schema.StringAttribute{
+				Description: "The name or ID of a sub-technique of technique, such as 'Keylogging', '001' or 'T1056.001'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("technique")),
+				},
+			},
+			"catalog_version": schema.StringAttribute{
+				Description: "The ATT&CK release of the embedded catalog.",
+				Computed:    true,
+			},
+			"labels": schema.ListAttribute{
+				Description: "The matching labels, such as 'Enterprise.TA0009.T1056.001', ready to use in armis_policy.mitre_attack_labels.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"techniques": schema.ListNestedAttribute{
+				Description: "A computed list of matching techniques. Each object in the list contains detailed information about a technique under one tactic.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							Description: "The label of the technique, such as 'Enterprise.TA0009.T1056.001'.",
+							Computed:    true,
+						},
+						"tactic_id": schema.StringAttribute{
+							Description: "The ID of the tactic, such as 'TA0009'.",
+							Computed:    true,
+						},
+						"tactic_name": schema.StringAttribute{
+							Description: "The name of the tactic, such as 'Collection'.",
+							Computed:    true,
+						},
+						"technique_id": schema.StringAttribute{
+							Description: "The ID of the technique, such as 'T1056'.",
+							Computed:    true,
+						},
+						"technique_name": schema.StringAttribute{
+							Description: "The name of the technique, such as 'Input Capture'.",
+							Computed:    true,
+						},
+						"sub_technique_id": schema.StringAttribute{
+							Description: "The ID of the sub-technique, such as 'T1056.001', when one was requested.",
+							Computed:    true,
+						},
+						"sub_technique_name": schema.StringAttribute{
+							Description: "The name of the sub-technique, such as 'Keylogging', when one was requested.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mitreAttackDataSourceModel maps the data source schema data.
+type mitreAttackDataSourceModel struct {
+	Matrix         types.String          `tfsdk:"matrix"`
+	Tactic         types.String          `tfsdk:"tactic"`
+	Technique      types.String          `tfsdk:"technique"`
+	SubTechnique   types.String          `tfsdk:"sub_technique"`
+	CatalogVersion types.String          `tfsdk:"catalog_version"`
+	Labels         []types.String        `tfsdk:"labels"`
+	Techniques     []mitreTechniqueModel `tfsdk:"techniques"`
+}
+
+// mitreTechniqueModel maps the technique schema data.
+type mitreTechniqueModel struct {
+	Label            types.String `tfsdk:"label"`
+	TacticID         types.String `tfsdk:"tactic_id"`
+	TacticName       types.String `tfsdk:"tactic_name"`
+	TechniqueID      types.String `tfsdk:"technique_id"`
+	TechniqueName    types.String `tfsdk:"technique_name"`
+	SubTechniqueID   types.String `tfsdk:"sub_technique_id"`
+	SubTechniqueName types.String `tfsdk:"sub_technique_name"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *mitreAttackDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state mitreAttackDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog := mitre.Default()
+
+	matrixName := defaultMitreMatrix
+	if !state.Matrix.IsNull() {
+		matrixName = state.Matrix.ValueString()
+	}
+	matrix, err := catalog.LookupMatrix(matrixName)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("matrix"), "Unknown MITRE ATT&CK Matrix", err.Error())
+		return
+	}
+
+	var tactic *mitre.Tactic
+	if !state.Tactic.IsNull() {
+		tactic, err = matrix.LookupTactic(state.Tactic.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("tactic"), "Unknown MITRE ATT&CK Tactic", err.Error())
+			return
+		}
+	}
+
+	// Map catalog entries to model
+	var techniques []mitreTechniqueModel
+	if state.Technique.IsNull() {
+		for _, technique := range matrix.TacticTechniques(tactic.ID) {
+			techniques = append(techniques, newMitreTechniqueModel(matrix, tactic, technique, nil))
+		}
+	} else {
+		technique, err := matrix.LookupTechnique(state.Technique.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("technique"), "Unknown MITRE ATT&CK Technique", err.Error())
+			return
+		}
+
+		var sub *mitre.SubTechnique
+		if !state.SubTechnique.IsNull() {
+			sub, err = technique.LookupSubTechnique(state.SubTechnique.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("sub_technique"), "Unknown MITRE ATT&CK Sub-technique", err.Error())
+				return
+			}
+		}
+
+		if tactic != nil {
+			label := mitre.Label{Matrix: matrix.Name, Tactic: tactic.ID, Technique: technique.ID}
+			if err := catalog.CheckLabel(label); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("technique"), "MITRE ATT&CK Technique Not In Tactic", err.Error())
+				return
+			}
+			techniques = append(techniques, newMitreTechniqueModel(matrix, tactic, technique, sub))
+		} else {
+			for _, tacticID := range technique.Tactics {
+				techniques = append(techniques, newMitreTechniqueModel(matrix, matrix.Tactic(tacticID), technique, sub))
+			}
+		}
+	}
+
+	labels := make([]types.String, 0, len(techniques))
+	for _, technique := range techniques {
+		labels = append(labels, technique.Label)
+	}
+
+	// Save data into Terraform state
+	state.Matrix = types.StringValue(matrix.Name)
+	state.CatalogVersion = types.StringValue(catalog.Version)
+	state.Labels = labels
+	state.Techniques = techniques
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// newMitreTechniqueModel maps a technique under tactic, and optionally one of
+// its sub-techniques, to the technique schema data.
+func newMitreTechniqueModel(matrix *mitre.Matrix, tactic *mitre.Tactic, technique *mitre.Technique, sub *mitre.SubTechnique) mitreTechniqueModel {
+	label := mitre.Label{Matrix: matrix.Name, Tactic: tactic.ID, Technique: technique.ID}
+	model := mitreTechniqueModel{
+		TacticID:         types.StringValue(tactic.ID),
+		TacticName:       types.StringValue(tactic.Name),
+		TechniqueID:      types.StringValue(technique.ID),
+		TechniqueName:    types.StringValue(technique.Name),
+		SubTechniqueID:   types.StringNull(),
+		SubTechniqueName: types.StringNull(),
+	}
+
+	if sub != nil {
+		label.SubTechnique = sub.ID
+		model.SubTechniqueID = types.StringValue(technique.ID + "." + sub.ID)
+		model.SubTechniqueName = types.StringValue(sub.Name)
+	}
+	model.Label = types.StringValue(label.String())
+
+	return model
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_MitreAttackDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMitreAttackDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.phishing", "matrix", "Enterprise"),
+					resource.TestCheckResourceAttrSet("data.armis_mitre_attack.phishing", "catalog_version"),
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.phishing", "labels.#", "1"),
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.phishing", "labels.0", "Enterprise.TA0001.T1566"),
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.keylogging", "labels.#", "2"),
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.keylogging", "labels.0", "Enterprise.TA0006.T1056.001"),
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.keylogging", "labels.1", "Enterprise.TA0009.T1056.001"),
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.keylogging", "techniques.0.sub_technique_id", "T1056.001"),
+					resource.TestCheckResourceAttr("data.armis_mitre_attack.keylogging", "techniques.0.sub_technique_name", "Keylogging"),
+					resource.TestCheckResourceAttrSet("data.armis_mitre_attack.ics_impact", "techniques.0.technique_name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMitreAttackDataSourceConfig() string {
+	return `
+data "armis_mitre_attack" "phishing" {
+  tactic    = "Initial Access"
+  technique = "Phishing"
+}
+
+data "armis_mitre_attack" "keylogging" {
+  technique     = "Input Capture"
+  sub_technique = "Keylogging"
+}
+
+data "armis_mitre_attack" "ics_impact" {
+  matrix = "ICS"
+  tactic = "Impact"
+}
+`
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
+	u "github.com/1898andCo/terraform-provider-armis-centrix/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// buildMitreCoverage records the MITRE ATT&CK labels of the enabled policies
+// selected by the prefix filters. It returns the IDs of the policies with at
+// least one label and a description of each label missing from the catalog.
+func buildMitreCoverage(catalog *mitre.Catalog, policies []armis.SinglePolicy, matchPrefix, excludePrefix types.String) (*mitre.Coverage, []string, []string) {
+	coverage := mitre.NewCoverage()
+	policyIDs := make(map[string]struct{})
+	var unknown []string
+
+	for _, policy := range policies {
+		// Only the name is used to filter policies, so rules the data source
+		// model cannot represent do not matter here.
+		model, _ := u.BuildPolicyDataSourceModelFromSingle(policy)
+		if !policy.IsEnabled || !u.ShouldIncludePolicy(model, matchPrefix) || u.ShouldExcludePolicy(model, excludePrefix) {
+			continue
+		}
+
+		for _, apiLabel := range policy.MitreAttackLabels {
+			label, err := catalog.Resolve(apiLabel.Matrix, apiLabel.Tactic, apiLabel.Technique, apiLabel.SubTechnique)
+			if err != nil {
+				unknown = append(unknown, fmt.Sprintf("- policy %q (%s): %s", policy.Name, policy.ID, err))
+				continue
+			}
+			coverage.Add(label, policy.ID)
+			policyIDs[policy.ID] = struct{}{}
+		}
+	}
+
+	return coverage, slices.Sorted(maps.Keys(policyIDs)), unknown
+}
+
+// newMitreCoverageMatrixModel maps the coverage of the techniques of matrix
+// under each of their tactics to the matrix schema data.
+func newMitreCoverageMatrixModel(matrix *mitre.Matrix, coverage *mitre.Coverage) mitreCoverageMatrixModel {
+	tactics := make(map[string]mitreCoverageTacticModel, len(matrix.Tactics))
+	matrixPolicies := make(map[string]struct{})
+	coveredTechniques := make(map[string]struct{})
+
+	for _, tactic := range matrix.Tactics {
+		techniques := matrix.TacticTechniques(tactic.ID)
+		model := mitreCoverageTacticModel{
+			Name:                types.StringValue(tactic.Name),
+			TotalTechniques:     types.Int64Value(int64(len(techniques))),
+			UncoveredTechniques: []types.String{},
+			Techniques:          make(map[string]mitreCoverageTechniqueModel, len(techniques)),
+		}
+		tacticPolicies := make(map[string]struct{})
+
+		for _, technique := range techniques {
+			ids := coverage.PolicyIDs(mitre.Label{Matrix: matrix.Name, Tactic: tactic.ID, Technique: technique.ID})
+			model.Techniques[technique.ID] = mitreCoverageTechniqueModel{
+				Name:      types.StringValue(technique.Name),
+				Covered:   types.BoolValue(len(ids) > 0),
+				PolicyIDs: mitreCoveragePolicyIDs(ids),
+			}
+
+			if len(ids) == 0 {
+				model.UncoveredTechniques = append(model.UncoveredTechniques, types.StringValue(technique.ID))
+				continue
+			}
+			coveredTechniques[technique.ID] = struct{}{}
+			for _, id := range ids {
+				tacticPolicies[id] = struct{}{}
+				matrixPolicies[id] = struct{}{}
+			}
+		}
+
+		covered := len(techniques) - len(model.UncoveredTechniques)
+		model.Covered = types.BoolValue(covered > 0)
+		model.CoveredTechniques = types.Int64Value(int64(covered))
+		model.PolicyIDs = mitreCoveragePolicyIDs(slices.Sorted(maps.Keys(tacticPolicies)))
+		tactics[tactic.ID] = model
+	}
+
+	uncovered := []types.String{}
+	for _, technique := range matrix.Techniques {
+		if _, ok := coveredTechniques[technique.ID]; !ok {
+			uncovered = append(uncovered, types.StringValue(technique.ID))
+		}
+	}
+
+	return mitreCoverageMatrixModel{
+		TotalTechniques:     types.Int64Value(int64(len(matrix.Techniques))),
+		CoveredTechniques:   types.Int64Value(int64(len(coveredTechniques))),
+		PolicyIDs:           mitreCoveragePolicyIDs(slices.Sorted(maps.Keys(matrixPolicies))),
+		UncoveredTechniques: uncovered,
+		Tactics:             tactics,
+	}
+}
+
+// mitreCoveragePolicyIDs converts policy IDs to an empty rather than null list
+// when there are none, so that they can be passed to length in conditions.
+func mitreCoveragePolicyIDs(ids []string) []types.String {
+	result := make([]types.String, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, types.StringValue(id))
+	}
+
+	return result
+}
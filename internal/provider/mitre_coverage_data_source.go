@@ -0,0 +1,253 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &mitreCoverageDataSource{}
+	_ datasource.DataSourceWithConfigure = &mitreCoverageDataSource{}
+)
+
+// Configure adds the provider configured client to the data source.
+func (d *mitreCoverageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.cache = data.Cache
+}
+
+// MitreCoverageDataSource is a helper function to simplify the provider implementation.
+func MitreCoverageDataSource() datasource.DataSource {
+	return &mitreCoverageDataSource{}
+}
+
+// mitreCoverageDataSource is the data source implementation.
+type mitreCoverageDataSource struct {
+	cache *cache.Cache
+}
+
+// Metadata returns the data source type name.
+func (d *mitreCoverageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mitre_coverage"
+}
+
+// Schema defines the schema for the MITRE coverage data source.
+func (d *mitreCoverageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	policyIDs := schema.ListAttribute{
+		Description: "The sorted IDs of the enabled policies covering at least one technique.",
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+	uncovered := schema.ListAttribute{
+		Description: "The IDs of the techniques that no enabled policy covers, in catalog order.",
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+	total := schema.Int64Attribute{
+		Description: "The number of techniques.",
+		Computed:    true,
+	}
+	covered := schema.Int64Attribute{
+		Description: "The number of techniques covered by at least one enabled policy.",
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Aggregates the mitre_attack_labels of all enabled Armis policies into a MITRE ATT&CK coverage map " +
+			"keyed by matrix name, tactic ID and technique ID. Use it in check blocks to assert that techniques are covered.",
+		Attributes: map[string]schema.Attribute{
+			"matrix": schema.StringAttribute{
+				Description: "Optional filter to include only this matrix. Valid options include 'Enterprise', 'Mobile', and 'ICS'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("Enterprise", "Mobile", "ICS"),
+				},
+			},
+			"match_prefix": schema.StringAttribute{
+				Description: "Optional filter to include only policies whose names start with this prefix.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"exclude_prefix": schema.StringAttribute{
+				Description: "Optional filter to exclude policies whose names start with this prefix.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"catalog_version": schema.StringAttribute{
+				Description: "The ATT&CK release of the embedded catalog.",
+				Computed:    true,
+			},
+			"policy_ids": schema.ListAttribute{
+				Description: "The sorted IDs of the enabled policies that have at least one MITRE ATT&CK label.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"matrices": schema.MapNestedAttribute{
+				Description: "The coverage of each matrix, keyed by matrix name such as 'Enterprise'.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"total_techniques":     total,
+						"covered_techniques":   covered,
+						"policy_ids":           policyIDs,
+						"uncovered_techniques": uncovered,
+						"tactics": schema.MapNestedAttribute{
+							Description: "The coverage of each tactic of the matrix, keyed by tactic ID such as 'TA0001'.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Description: "The name of the tactic, such as 'Initial Access'.",
+										Computed:    true,
+									},
+									"covered": schema.BoolAttribute{
+										Description: "Whether at least one technique of the tactic is covered.",
+										Computed:    true,
+									},
+									"total_techniques":     total,
+									"covered_techniques":   covered,
+									"policy_ids":           policyIDs,
+									"uncovered_techniques": uncovered,
+									"techniques": schema.MapNestedAttribute{
+										Description: "The coverage of each technique of the tactic, keyed by technique ID such as 'T1566'. " +
+											"A label with a sub-technique covers its technique.",
+										Computed: true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"name": schema.StringAttribute{
+													Description: "The name of the technique, such as 'Phishing'.",
+													Computed:    true,
+												},
+												"covered": schema.BoolAttribute{
+													Description: "Whether at least one enabled policy covers the technique under this tactic.",
+													Computed:    true,
+												},
+												"policy_ids": schema.ListAttribute{
+													Description: "The sorted IDs of the enabled policies covering the technique under this tactic.",
+													Computed:    true,
+													ElementType: types.StringType,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mitreCoverageDataSourceModel maps the data source schema data.
+type mitreCoverageDataSourceModel struct {
+	Matrix         types.String                        `tfsdk:"matrix"`
+	MatchPrefix    types.String                        `tfsdk:"match_prefix"`
+	ExcludePrefix  types.String                        `tfsdk:"exclude_prefix"`
+	CatalogVersion types.String                        `tfsdk:"catalog_version"`
+	PolicyIDs      []types.String                      `tfsdk:"policy_ids"`
+	Matrices       map[string]mitreCoverageMatrixModel `tfsdk:"matrices"`
+}
+
+// mitreCoverageMatrixModel maps the matrix schema data.
+type mitreCoverageMatrixModel struct {
+	TotalTechniques     types.Int64                         `tfsdk:"total_techniques"`
+	CoveredTechniques   types.Int64                         `tfsdk:"covered_techniques"`
+	PolicyIDs           []types.String                      `tfsdk:"policy_ids"`
+	UncoveredTechniques []types.String                      `tfsdk:"uncovered_techniques"`
+	Tactics             map[string]mitreCoverageTacticModel `tfsdk:"tactics"`
+}
+
+// mitreCoverageTacticModel maps the tactic schema data.
+type mitreCoverageTacticModel struct {
+	Name                types.String                           `tfsdk:"name"`
+	Covered             types.Bool                             `tfsdk:"covered"`
+	TotalTechniques     types.Int64                            `tfsdk:"total_techniques"`
+	CoveredTechniques   types.Int64                            `tfsdk:"covered_techniques"`
+	PolicyIDs           []types.String                         `tfsdk:"policy_ids"`
+	UncoveredTechniques []types.String                         `tfsdk:"uncovered_techniques"`
+	Techniques          map[string]mitreCoverageTechniqueModel `tfsdk:"techniques"`
+}
+
+// mitreCoverageTechniqueModel maps the technique schema data.
+type mitreCoverageTechniqueModel struct {
+	Name      types.String   `tfsdk:"name"`
+	Covered   types.Bool     `tfsdk:"covered"`
+	PolicyIDs []types.String `tfsdk:"policy_ids"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *mitreCoverageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state mitreCoverageDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := d.cache.Policies(ctx)
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Policies", err)
+		return
+	}
+
+	catalog := mitre.Default()
+	coverage, policyIDs, unknown := buildMitreCoverage(catalog, policies, state.MatchPrefix, state.ExcludePrefix)
+	if len(unknown) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Unknown MITRE ATT&CK Labels",
+			fmt.Sprintf("The following labels of enabled policies are not in the MITRE ATT&CK v%s catalog and were not counted:\n\n%s",
+				catalog.Version, strings.Join(unknown, "\n")),
+		)
+	}
+
+	// Map coverage to model
+	matrices := make(map[string]mitreCoverageMatrixModel, len(catalog.Matrices))
+	for _, matrix := range catalog.Matrices {
+		if !state.Matrix.IsNull() && !strings.EqualFold(state.Matrix.ValueString(), matrix.Name) {
+			continue
+		}
+		matrices[matrix.Name] = newMitreCoverageMatrixModel(matrix, coverage)
+	}
+
+	// Save data into Terraform state
+	state.CatalogVersion = types.StringValue(catalog.Version)
+	state.PolicyIDs = mitreCoveragePolicyIDs(policyIDs)
+	state.Matrices = matrices
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_MitreCoverageDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMitreCoverageDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.armis_mitre_coverage.test", "catalog_version"),
+					resource.TestCheckResourceAttr("data.armis_mitre_coverage.test", "matrices.%", "1"),
+					resource.TestCheckResourceAttrSet("data.armis_mitre_coverage.test", "matrices.Enterprise.total_techniques"),
+					resource.TestCheckResourceAttrSet("data.armis_mitre_coverage.test", "matrices.Enterprise.covered_techniques"),
+					resource.TestCheckResourceAttr("data.armis_mitre_coverage.test", "matrices.Enterprise.tactics.TA0001.name", "Initial Access"),
+					resource.TestCheckResourceAttrSet("data.armis_mitre_coverage.test", "matrices.Enterprise.tactics.TA0001.techniques.T1566.covered"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMitreCoverageDataSourceConfig() string {
+	return `
+data "armis_mitre_coverage" "test" {
+  matrix = "Enterprise"
+}
+`
+}
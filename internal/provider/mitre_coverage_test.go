@@ -0,0 +1,294 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBuildMitreCoverage(t *testing.T) {
+	t.Parallel()
+
+	keylogging := armis.MitreAttackLabel{Matrix: "Enterprise", Tactic: "Collection", Technique: "Input Capture", SubTechnique: "T1056.001"}
+	inputCapture := armis.MitreAttackLabel{Matrix: "Enterprise", Tactic: "Credential Access", Technique: "Input Capture"}
+	phishing := armis.MitreAttackLabel{Matrix: "Enterprise", Tactic: "Initial Access", Technique: "Phishing"}
+	retired := armis.MitreAttackLabel{Matrix: "Enterprise", Tactic: "Collection", Technique: "Retired Technique"}
+
+	collectionT1056 := mitre.Label{Matrix: "Enterprise", Tactic: "TA0009", Technique: "T1056"}
+	credentialAccessT1056 := mitre.Label{Matrix: "Enterprise", Tactic: "TA0006", Technique: "T1056"}
+	initialAccessT1566 := mitre.Label{Matrix: "Enterprise", Tactic: "TA0001", Technique: "T1566"}
+
+	tests := []struct {
+		name          string
+		policies      []armis.SinglePolicy
+		matchPrefix   types.String
+		excludePrefix types.String
+		wantPolicyIDs []string
+		wantCoverage  map[mitre.Label][]string
+		wantUnknown   int
+	}{
+		{
+			name: "sub-technique rolls up to its technique",
+			policies: []armis.SinglePolicy{
+				{ID: "1", Name: "Keylogging", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{keylogging}},
+			},
+			wantPolicyIDs: []string{"1"},
+			wantCoverage: map[mitre.Label][]string{
+				collectionT1056:       {"1"},
+				credentialAccessT1056: {},
+			},
+		},
+		{
+			name: "technique under several tactics",
+			policies: []armis.SinglePolicy{
+				{ID: "2", Name: "Keylogging", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{keylogging}},
+				{ID: "1", Name: "Credential Capture", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{inputCapture, keylogging}},
+			},
+			wantPolicyIDs: []string{"1", "2"},
+			wantCoverage: map[mitre.Label][]string{
+				collectionT1056:       {"1", "2"},
+				credentialAccessT1056: {"1"},
+			},
+		},
+		{
+			name: "disabled policies are skipped",
+			policies: []armis.SinglePolicy{
+				{ID: "1", Name: "Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+				{ID: "2", Name: "Old Phishing", IsEnabled: false, MitreAttackLabels: []armis.MitreAttackLabel{phishing, keylogging}},
+			},
+			wantPolicyIDs: []string{"1"},
+			wantCoverage: map[mitre.Label][]string{
+				initialAccessT1566: {"1"},
+				collectionT1056:    {},
+			},
+		},
+		{
+			name: "unlabeled policies are not listed",
+			policies: []armis.SinglePolicy{
+				{ID: "1", Name: "Unlabeled", IsEnabled: true},
+			},
+			wantCoverage: map[mitre.Label][]string{
+				initialAccessT1566: {},
+			},
+		},
+		{
+			name: "match prefix",
+			policies: []armis.SinglePolicy{
+				{ID: "1", Name: "SOC Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+				{ID: "2", Name: "Vendor Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+			},
+			matchPrefix:   types.StringValue("SOC"),
+			wantPolicyIDs: []string{"1"},
+			wantCoverage: map[mitre.Label][]string{
+				initialAccessT1566: {"1"},
+			},
+		},
+		{
+			name: "exclude prefix",
+			policies: []armis.SinglePolicy{
+				{ID: "1", Name: "SOC Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+				{ID: "2", Name: "SOC Draft Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+				{ID: "3", Name: "Vendor Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+			},
+			excludePrefix: types.StringValue("SOC Draft"),
+			wantPolicyIDs: []string{"1", "3"},
+			wantCoverage: map[mitre.Label][]string{
+				initialAccessT1566: {"1", "3"},
+			},
+		},
+		{
+			name: "match and exclude prefix",
+			policies: []armis.SinglePolicy{
+				{ID: "1", Name: "SOC Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+				{ID: "2", Name: "SOC Draft Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+				{ID: "3", Name: "Vendor Phishing", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{phishing}},
+			},
+			matchPrefix:   types.StringValue("SOC"),
+			excludePrefix: types.StringValue("SOC Draft"),
+			wantPolicyIDs: []string{"1"},
+			wantCoverage: map[mitre.Label][]string{
+				initialAccessT1566: {"1"},
+			},
+		},
+		{
+			name: "labels missing from the catalog are reported",
+			policies: []armis.SinglePolicy{
+				{ID: "1", Name: "Retired", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{retired, phishing}},
+				{ID: "2", Name: "Only Retired", IsEnabled: true, MitreAttackLabels: []armis.MitreAttackLabel{retired}},
+			},
+			wantPolicyIDs: []string{"1"},
+			wantCoverage: map[mitre.Label][]string{
+				initialAccessT1566: {"1"},
+			},
+			wantUnknown: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coverage, policyIDs, unknown := buildMitreCoverage(mitre.Default(), tt.policies, tt.matchPrefix, tt.excludePrefix)
+
+			if !slices.Equal(policyIDs, tt.wantPolicyIDs) {
+				t.Errorf("policy IDs = %v, want %v", policyIDs, tt.wantPolicyIDs)
+			}
+			if len(unknown) != tt.wantUnknown {
+				t.Errorf("unknown labels = %v, want %d", unknown, tt.wantUnknown)
+			}
+			for label, want := range tt.wantCoverage {
+				if got := coverage.PolicyIDs(label); !slices.Equal(got, want) {
+					t.Errorf("PolicyIDs(%s) = %v, want %v", label, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewMitreCoverageMatrixModel(t *testing.T) {
+	t.Parallel()
+
+	catalog := mitre.Default()
+
+	keylogging := mitre.Label{Matrix: "Enterprise", Tactic: "TA0009", Technique: "T1056", SubTechnique: "001"}
+	credentialAccessT1056 := mitre.Label{Matrix: "Enterprise", Tactic: "TA0006", Technique: "T1056"}
+	phishing := mitre.Label{Matrix: "Enterprise", Tactic: "TA0001", Technique: "T1566"}
+
+	type covered struct {
+		label    mitre.Label
+		policyID string
+	}
+	type tacticWant struct {
+		covered           bool
+		coveredTechniques int64
+		policyIDs         []string
+	}
+
+	tests := []struct {
+		name           string
+		matrix         string
+		covered        []covered
+		wantCovered    int64
+		wantPolicyIDs  []string
+		wantTactics    map[string]tacticWant
+		wantTechniques map[string][]string
+	}{
+		{
+			name:          "no coverage",
+			matrix:        "Enterprise",
+			wantCovered:   0,
+			wantPolicyIDs: []string{},
+			wantTactics: map[string]tacticWant{
+				"TA0009": {policyIDs: []string{}},
+			},
+			wantTechniques: map[string][]string{
+				"TA0009/T1056": {},
+			},
+		},
+		{
+			name:          "sub-technique rolls up to its technique",
+			matrix:        "Enterprise",
+			covered:       []covered{{keylogging, "1"}},
+			wantCovered:   1,
+			wantPolicyIDs: []string{"1"},
+			wantTactics: map[string]tacticWant{
+				"TA0009": {covered: true, coveredTechniques: 1, policyIDs: []string{"1"}},
+				"TA0006": {policyIDs: []string{}},
+			},
+			wantTechniques: map[string][]string{
+				"TA0009/T1056": {"1"},
+				"TA0006/T1056": {},
+			},
+		},
+		{
+			name:          "technique under several tactics is counted once",
+			matrix:        "Enterprise",
+			covered:       []covered{{keylogging, "1"}, {credentialAccessT1056, "2"}, {phishing, "2"}},
+			wantCovered:   2,
+			wantPolicyIDs: []string{"1", "2"},
+			wantTactics: map[string]tacticWant{
+				"TA0009": {covered: true, coveredTechniques: 1, policyIDs: []string{"1"}},
+				"TA0006": {covered: true, coveredTechniques: 1, policyIDs: []string{"2"}},
+				"TA0001": {covered: true, coveredTechniques: 1, policyIDs: []string{"2"}},
+			},
+			wantTechniques: map[string][]string{
+				"TA0009/T1056": {"1"},
+				"TA0006/T1056": {"2"},
+				"TA0001/T1566": {"2"},
+			},
+		},
+		{
+			name:          "other matrices are not covered",
+			matrix:        "ICS",
+			covered:       []covered{{keylogging, "1"}, {phishing, "2"}},
+			wantCovered:   0,
+			wantPolicyIDs: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coverage := mitre.NewCoverage()
+			for _, c := range tt.covered {
+				coverage.Add(c.label, c.policyID)
+			}
+
+			matrix := catalog.Matrix(tt.matrix)
+			model := newMitreCoverageMatrixModel(matrix, coverage)
+
+			if got := model.TotalTechniques.ValueInt64(); got != int64(len(matrix.Techniques)) {
+				t.Errorf("total techniques = %d, want %d", got, len(matrix.Techniques))
+			}
+			if got := model.CoveredTechniques.ValueInt64(); got != tt.wantCovered {
+				t.Errorf("covered techniques = %d, want %d", got, tt.wantCovered)
+			}
+			if got, want := len(model.UncoveredTechniques), len(matrix.Techniques)-int(tt.wantCovered); got != want {
+				t.Errorf("uncovered techniques = %d, want %d", got, want)
+			}
+			if model.PolicyIDs == nil || !slices.Equal(typesStringSliceToStrings(model.PolicyIDs), tt.wantPolicyIDs) {
+				t.Errorf("policy IDs = %v, want %v", model.PolicyIDs, tt.wantPolicyIDs)
+			}
+
+			for tacticID, want := range tt.wantTactics {
+				tactic := model.Tactics[tacticID]
+				if got := tactic.Covered.ValueBool(); got != want.covered {
+					t.Errorf("%s covered = %t, want %t", tacticID, got, want.covered)
+				}
+				if got := tactic.CoveredTechniques.ValueInt64(); got != want.coveredTechniques {
+					t.Errorf("%s covered techniques = %d, want %d", tacticID, got, want.coveredTechniques)
+				}
+				if got := tactic.TotalTechniques.ValueInt64(); got != int64(len(matrix.TacticTechniques(tacticID))) {
+					t.Errorf("%s total techniques = %d, want %d", tacticID, got, len(matrix.TacticTechniques(tacticID)))
+				}
+				if tactic.PolicyIDs == nil || !slices.Equal(typesStringSliceToStrings(tactic.PolicyIDs), want.policyIDs) {
+					t.Errorf("%s policy IDs = %v, want %v", tacticID, tactic.PolicyIDs, want.policyIDs)
+				}
+			}
+
+			for key, want := range tt.wantTechniques {
+				tacticID, techniqueID, _ := strings.Cut(key, "/")
+				technique := model.Tactics[tacticID].Techniques[techniqueID]
+				if got := technique.Covered.ValueBool(); got != (len(want) > 0) {
+					t.Errorf("%s covered = %t, want %t", key, got, len(want) > 0)
+				}
+				if technique.PolicyIDs == nil || !slices.Equal(typesStringSliceToStrings(technique.PolicyIDs), want) {
+					t.Errorf("%s policy IDs = %v, want %v", key, technique.PolicyIDs, want)
+				}
+				uncovered := slices.Contains(model.Tactics[tacticID].UncoveredTechniques, types.StringValue(techniqueID))
+				if uncovered != (len(want) == 0) {
+					t.Errorf("%s listed as uncovered = %t, want %t", key, uncovered, len(want) == 0)
+				}
+			}
+		})
+	}
+}
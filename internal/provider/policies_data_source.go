@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/asqtypes"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 	u "github.com/1898andCo/terraform-provider-armis-centrix/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -22,6 +24,7 @@ var (
 
 type policiesDataSource struct {
 	client *armis.Client
+	cache  *cache.Cache
 }
 
 func PoliciesDataSource() datasource.DataSource {
@@ -33,16 +36,17 @@ func (d *policiesDataSource) Configure(_ context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
+	d.cache = data.Cache
 }
 
 func (d *policiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -186,6 +190,11 @@ func (d *policiesDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 									Description: "OR conditions configured for the policy.",
 									ElementType: types.StringType,
 								},
+								"operator": schema.StringAttribute{
+									Computed:    true,
+									Description: "Rules joined by the groups, either 'and' or 'or'.",
+								},
+								"groups": policyRuleGroupsDataSourceAttribute(1),
 							},
 						},
 					},
@@ -195,6 +204,38 @@ func (d *policiesDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 	}
 }
 
+// policyRuleGroupsDataSourceAttribute returns the schema of the rule groups
+// nested depth levels deep, starting at 1 for the groups of rules.
+func policyRuleGroupsDataSourceAttribute(depth int) schema.ListNestedAttribute {
+	attributes := map[string]schema.Attribute{
+		"and": schema.ListAttribute{
+			Computed:    true,
+			Description: "AND conditions of the group.",
+			ElementType: asqtypes.QueryType{},
+		},
+		"or": schema.ListAttribute{
+			Computed:    true,
+			Description: "OR conditions of the group.",
+			ElementType: asqtypes.QueryType{},
+		},
+	}
+	if depth < u.MaxRuleGroupDepth {
+		attributes["operator"] = schema.StringAttribute{
+			Computed:    true,
+			Description: "Conditions joined by the groups of the group, either 'and' or 'or'.",
+		}
+		attributes["groups"] = policyRuleGroupsDataSourceAttribute(depth + 1)
+	}
+
+	return schema.ListNestedAttribute{
+		Computed:    true,
+		Description: "Groups of conditions, each evaluated as a single condition such as (A OR B).",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+	}
+}
+
 func (d *policiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var config u.PoliciesDataSourceModel
 
@@ -208,35 +249,35 @@ func (d *policiesDataSource) Read(ctx context.Context, req datasource.ReadReques
 	if !config.PolicyID.IsNull() && config.PolicyID.ValueString() != "" {
 		policy, err := d.client.GetPolicy(ctx, config.PolicyID.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis Policy",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis Policy", err)
 			return
 		}
 
-		model := u.BuildPolicyDataSourceModelFromGet(policy, config.PolicyID.ValueString())
+		model, diags := u.BuildPolicyDataSourceModelFromGet(policy, config.PolicyID.ValueString())
+		resp.Diagnostics.Append(diags...)
 		if u.ShouldIncludePolicy(model, config.MatchPrefix) && !u.ShouldExcludePolicy(model, config.ExcludePrefix) {
 			policies = append(policies, model)
 		}
 	} else {
-		allPolicies, err := d.client.GetAllPolicies(ctx)
+		allPolicies, err := d.cache.Policies(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis Policies",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis Policies", err)
 			return
 		}
 
 		for _, policy := range allPolicies {
-			model := u.BuildPolicyDataSourceModelFromSingle(policy)
+			model, diags := u.BuildPolicyDataSourceModelFromSingle(policy)
+			resp.Diagnostics.Append(diags...)
 			if u.ShouldIncludePolicy(model, config.MatchPrefix) && !u.ShouldExcludePolicy(model, config.ExcludePrefix) {
 				policies = append(policies, model)
 			}
 		}
 	}
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	config.Policies = policies
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
@@ -12,6 +12,8 @@ import (
 	"strconv"
 
 	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/asqtypes"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 	u "github.com/1898andCo/terraform-provider-armis-centrix/internal/utils"
 	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
 
@@ -37,7 +39,9 @@ var (
 )
 
 type policyResource struct {
-	client *armis.Client
+	client   *armis.Client
+	readOnly bool
+	cache    *cache.Cache
 }
 
 func PolicyResource() resource.Resource {
@@ -50,16 +54,18 @@ func (r *policyResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+	r.cache = data.Cache
 }
 
 // Metadata returns the resource type name.
@@ -109,7 +115,7 @@ func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			"mitre_attack_labels": schema.ListAttribute{
 				Optional:    true,
-				Description: "A list of MITRE ATT&CK labels to apply to the policy.",
+				Description: "A list of MITRE ATT&CK labels to apply to the policy, in the format Matrix.TacticID.TechniqueID[.SubTechniqueID], such as 'Enterprise.TA0009.T1056.001'. Labels are read back from Armis, so changes made outside Terraform show as drift.",
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
@@ -201,17 +207,87 @@ func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Attributes: map[string]schema.Attribute{
 					"and": schema.ListAttribute{
 						Optional:    true,
-						Description: "A list of AND rules to apply to the policy.",
-						ElementType: types.StringType,
+						Description: "A list of AND rules to apply to the policy. Each rule is an ASQ condition without in: terms, such as 'protocol:BMS'.",
+						ElementType: asqtypes.QueryType{},
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(verify.ValidASQRule()),
+						},
 					},
 					"or": schema.ListAttribute{
 						Optional:    true,
-						Description: "A list of OR rules to apply to the policy.",
-						ElementType: types.StringType,
+						Description: "A list of OR rules to apply to the policy. Each rule is an ASQ condition without in: terms, such as 'protocol:BMS'.",
+						ElementType: asqtypes.QueryType{},
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(verify.ValidASQRule()),
+						},
 					},
+					"operator": policyRuleOperatorAttribute(),
+					"groups":   policyRuleGroupsAttribute(1),
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// policyRuleOperatorAttribute returns the schema of the operator selecting
+// the rules that the groups of rules or of a rule group join.
+func policyRuleOperatorAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "The rules joined by the groups, either 'and' or 'or'. Required when groups are set.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(u.RuleOperatorAnd, u.RuleOperatorOr),
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("groups")),
+		},
+	}
+}
+
+// policyRuleGroupsAttribute returns the schema of the rule groups nested depth
+// levels deep, starting at 1 for the groups of rules. A rule group has the
+// attributes of rules, except that groups at u.MaxRuleGroupDepth cannot hold
+// further groups.
+func policyRuleGroupsAttribute(depth int) schema.ListNestedAttribute {
+	ruleList := func(operator string) schema.ListAttribute {
+		return schema.ListAttribute{
+			Optional:    true,
+			Description: fmt.Sprintf("A list of %s rules of the group. Each rule is an ASQ condition without in: terms, such as 'protocol:BMS'.", operator),
+			ElementType: asqtypes.QueryType{},
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(verify.ValidASQRule()),
+			},
+		}
+	}
+
+	and := ruleList("AND")
+	others := []path.Expression{path.MatchRelative().AtParent().AtName("or")}
+	attributes := map[string]schema.Attribute{
+		"or": ruleList("OR"),
+	}
+	if depth < u.MaxRuleGroupDepth {
+		others = append(others, path.MatchRelative().AtParent().AtName("groups"))
+		attributes["operator"] = policyRuleOperatorAttribute()
+		attributes["groups"] = policyRuleGroupsAttribute(depth + 1)
+	}
+	and.Validators = append(and.Validators, listvalidator.AtLeastOneOf(others...))
+	attributes["and"] = and
+
+	description := "A list of groups of rules, each evaluated as a single condition such as (A OR B) and joined to the rules selected by operator."
+	if depth == 1 {
+		description += fmt.Sprintf(" Groups can be nested up to %d levels deep.", u.MaxRuleGroupDepth)
+	}
+
+	return schema.ListNestedAttribute{
+		Optional:    true,
+		Description: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+		Validators: []validator.List{
+			listvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("operator")),
+		},
 	}
 }
 
@@ -220,12 +296,28 @@ func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 // policy ID in state, and writes the updated state back—aborting early whenever
 // diagnostics report an error.
 func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_policy")
+		return
+	}
+
+	defer r.cache.InvalidatePolicies()
+
 	var plan u.PolicyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	policy, diags := u.BuildPolicySettings(&plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -249,6 +341,15 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	getResp, err := r.client.GetPolicy(ctx, state.ID.ValueString())
 	if err != nil {
 		// Handle 404 Not Found by removing resource from state
@@ -266,20 +367,20 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	// Update state with the retrieved policy data
-	result := u.ResponseToPolicyFromGet(ctx, getResp)
-
-	if state.Rules != nil && result.Rules != nil {
-		if getResp.Rules.And == nil && !state.Rules.And.IsNull() && !state.Rules.And.IsUnknown() {
-			result.Rules.And = state.Rules.And
-		}
-		if getResp.Rules.Or == nil && !state.Rules.Or.IsNull() && !state.Rules.Or.IsUnknown() {
-			result.Rules.Or = state.Rules.Or
-		}
+	result, diags := u.ResponseToPolicyFromGet(ctx, getResp)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Preserve the ID and MITRE labels from state
+	u.PreserveRules(result.Rules, state.Rules, getResp.Rules)
+
+	// Rebuild the MITRE labels so that changes made outside Terraform show as drift
+	result.MitreAttackLabels = u.ReconcileMitreLabels(ctx, state.MitreAttackLabels, getResp.MitreAttackLabels)
+
+	// Preserve the ID and timeouts from state
 	result.ID = state.ID
-	result.MitreAttackLabels = state.MitreAttackLabels
+	result.Timeouts = state.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
 }
 
@@ -287,6 +388,13 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 // payload, calls r.client.UpdatePolicy with the existing ID, and writes the
 // (unchanged-ID) state back—bailing out on any diagnostics or API error.
 func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_policy")
+		return
+	}
+
+	defer r.cache.InvalidatePolicies()
+
 	var plan, state u.PolicyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -294,6 +402,15 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	policy, diags := u.BuildPolicySettings(&plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -307,35 +424,55 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update the plan with the response data
-	result := u.ResponseToPolicyFromUpdate(ctx, updateResp)
+	result, diags := u.ResponseToPolicyFromUpdate(ctx, updateResp)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if plan.Rules != nil && result.Rules != nil {
-		if updateResp.Rules.And == nil && !plan.Rules.And.IsNull() && !plan.Rules.And.IsUnknown() {
-			result.Rules.And = plan.Rules.And
-		}
-		if updateResp.Rules.Or == nil && !plan.Rules.Or.IsNull() && !plan.Rules.Or.IsUnknown() {
-			result.Rules.Or = plan.Rules.Or
-		}
+	u.PreserveRules(result.Rules, plan.Rules, updateResp.Rules)
+	// An update response without rules keeps the planned groups, which the
+	// next read then checks against the policy in Armis.
+	if updateResp.Rules.And == nil && updateResp.Rules.Or == nil && plan.Rules != nil && result.Rules != nil {
+		result.Rules.Operator = plan.Rules.Operator
+		result.Rules.Groups = plan.Rules.Groups
 	}
 
-	// Preserve the ID and MITRE labels
-	result.ID = state.ID
 	result.MitreAttackLabels = plan.MitreAttackLabels
+	if updateResp.MitreAttackLabels != nil {
+		result.MitreAttackLabels = u.ReconcileMitreLabels(ctx, plan.MitreAttackLabels, updateResp.MitreAttackLabels)
+	}
+
+	// Preserve the ID and timeouts
+	result.ID = state.ID
+	result.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
 }
 
 // Delete removes a policy of the provided ID.
 func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_policy")
+		return
+	}
+
+	defer r.cache.InvalidatePolicies()
+
 	var state u.PolicyResourceModel
 	if diags := req.State.Get(ctx, &state); diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	deleteResp, err := r.client.DeletePolicy(ctx, state.ID.ValueString())
 	if err != nil {
 		appendAPIError(&resp.Diagnostics, "Error deleting policy", err)
@@ -81,3 +81,72 @@ resource "armis_policy" "test" {
 }
 `, name)
 }
+
+func TestAcc_PolicyResourceRuleGroups(t *testing.T) {
+	resourceName := "armis_policy.test"
+
+	rName := strings.ToLower(acctest.RandomWithPrefix("tfacc-policy"))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyResourceRuleGroupsConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.and.0", "protocol:BMS"),
+					resource.TestCheckResourceAttr(resourceName, "rules.operator", "and"),
+					resource.TestCheckResourceAttr(resourceName, "rules.groups.0.operator", "or"),
+					resource.TestCheckResourceAttr(resourceName, "rules.groups.0.or.0", "protocol:SMB"),
+					resource.TestCheckResourceAttr(resourceName, "rules.groups.0.groups.0.and.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+			},
+		},
+	})
+}
+
+func testAccPolicyResourceRuleGroupsConfig(name string) string {
+	return fmt.Sprintf(`
+resource "armis_policy" "test" {
+  name        = %q
+  description = "protocol:BMS AND (protocol:SMB OR (protocol:HTTP AND port:8080))"
+  enabled     = true
+  rule_type   = "ACTIVITY"
+
+  actions = [
+    {
+      type = "alert"
+      params = {
+        severity = "high"
+        title    = "Test Security Alert"
+        type     = "Security - Threat"
+        consolidation = {
+          amount = 2
+          unit   = "Hours"
+        }
+      }
+    }
+  ]
+
+  rules = {
+    and      = ["protocol:BMS"]
+    operator = "and"
+    groups = [
+      {
+        or       = ["protocol:SMB"]
+        operator = "or"
+        groups = [
+          {
+            and = ["protocol:HTTP", "port:8080"]
+          }
+        ]
+      }
+    ]
+  }
+}
+`, name)
+}
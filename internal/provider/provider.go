@@ -9,22 +9,32 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 
 	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/credentials"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure ArmisProvider satisfies various provider interfaces.
 var (
-	_ provider.Provider = &ArmisProvider{}
+	_ provider.Provider                       = &ArmisProvider{}
+	_ provider.ProviderWithEphemeralResources = &ArmisProvider{}
 )
 
 // ArmisProvider defines the provider implementation.
@@ -34,8 +44,29 @@ type ArmisProvider struct {
 
 // ArmisProviderModel describes the provider data model.
 type ArmisProviderModel struct {
-	APIUrl types.String `tfsdk:"api_url"`
-	APIKey types.String `tfsdk:"api_key"`
+	APIUrl                    types.String    `tfsdk:"api_url"`
+	APIKey                    types.String    `tfsdk:"api_key"`
+	APIKeyFile                types.String    `tfsdk:"api_key_file"`
+	CredentialProcess         types.String    `tfsdk:"credential_process"`
+	CredentialCacheDir        types.String    `tfsdk:"credential_cache_dir"`
+	DisableLookupCache        types.Bool      `tfsdk:"disable_lookup_cache"`
+	MaxConcurrentRequests     types.Int64     `tfsdk:"max_concurrent_requests"`
+	ReadOnly                  types.Bool      `tfsdk:"read_only"`
+	SkipCredentialsValidation types.Bool      `tfsdk:"skip_credentials_validation"`
+	Retry                     *retryModel     `tfsdk:"retry"`
+	Transport                 *transportModel `tfsdk:"transport"`
+}
+
+// ArmisProviderData is handed to data sources, resources and ephemeral
+// resources when the provider is configured.
+type ArmisProviderData struct {
+	Client *armis.Client
+	// Cache serves the list endpoints for the rest of the Terraform
+	// operation. Resources invalidate it after changing what it holds.
+	Cache *cache.Cache
+	// ReadOnly makes resources refuse Create, Update and Delete before any
+	// request is sent to the Armis API.
+	ReadOnly bool
 }
 
 func (p *ArmisProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -54,11 +85,75 @@ func (p *ArmisProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "API Key for the Armis API.",
+				MarkdownDescription: "API Key for the Armis API. " + credentialPrecedenceDescription,
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key_file"), path.MatchRoot("credential_process")),
+				},
+			},
+			"api_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the API key for the Armis API. Surrounding whitespace is ignored. " +
+					"Can also be set with the `ARMIS_API_KEY_FILE` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("credential_process")),
+				},
+			},
+			"disable_lookup_cache": schema.BoolAttribute{
+				MarkdownDescription: "Send every list request (roles, users, sites, tags, policies and reports) to the Armis API " +
+					"instead of sharing one response between the data sources and resources of a Terraform operation. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the Armis API at the same time, shared by every resource " +
+					"and data source of the provider. A request counts until its response has been read. Lower it when parallel applies are throttled by the API. " +
+					"Requests waiting for a slot are logged at debug level. Unlimited by default. " +
+					"Can also be set with the `" + envMaxConcurrentRequests + "` environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to create, update or delete any resource. Refresh and data sources keep working, " +
+					"which makes the provider safe to use for drift detection and audits. " +
+					"Can also be set with the `ARMIS_READ_ONLY` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip the authenticated request made while the provider is configured to check that " +
+					"the Armis API is reachable and accepts the API key. Defaults to `false`.",
+				Optional: true,
+			},
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "Command that prints the API key for the Armis API on stdout as JSON, in the form " +
+					"`{\"Version\": 1, \"ApiKey\": \"...\", \"Expiration\": \"2025-01-01T00:00:00Z\"}`. " +
+					"`Expiration` is optional; when present the key is cached in memory until shortly before it expires, " +
+					"or until the Armis API rejects it. " +
+					"The command is split on whitespace and may use quotes; it is not run through a shell.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"credential_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory where keys returned by `credential_process` with an `Expiration` are also cached on disk, " +
+					"so that later Terraform runs reuse them instead of running the command again. The keys are written in plain text " +
+					"to files only readable by the current user. Unset by default, which keeps cached keys in memory only.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("credential_process")),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"retry":     retryBlockSchema(),
+			"transport": transportBlockSchema(),
+		},
 	}
 }
 
@@ -83,13 +178,22 @@ func (p *ArmisProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
-	if config.APIKey.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("api_key"),
-			"Unknown Armis API Key",
-			"The provider cannot create the Armis API client as there is an unknown configuration value for the API key. "+
-				"Set this value or use the ARMIS_API_KEY environment variable.",
-		)
+	for _, attr := range []struct {
+		name  string
+		value types.String
+	}{
+		{"api_key", config.APIKey},
+		{"api_key_file", config.APIKeyFile},
+		{"credential_process", config.CredentialProcess},
+	} {
+		if attr.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr.name),
+				"Unknown Armis API Key",
+				fmt.Sprintf("The provider cannot create the Armis API client as there is an unknown configuration value for %s. "+
+					"Set this value or use the ARMIS_API_KEY or ARMIS_API_KEY_FILE environment variables.", attr.name),
+			)
+		}
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -97,24 +201,36 @@ func (p *ArmisProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	}
 
 	// Default to environment variables if configuration is not provided
-	apiKey := os.Getenv("ARMIS_API_KEY")
 	apiURL := os.Getenv("ARMIS_API_URL")
 
-	if !config.APIKey.IsNull() {
-		apiKey = config.APIKey.ValueString()
-	}
-
 	if !config.APIUrl.IsNull() {
 		apiURL = config.APIUrl.ValueString()
 	}
 
+	resolver := credentials.NewResolver(config.CredentialCacheDir.ValueString())
+	credentialConfig := credentials.Config{
+		APIKey:            config.APIKey.ValueString(),
+		APIKeyFile:        config.APIKeyFile.ValueString(),
+		CredentialProcess: config.CredentialProcess.ValueString(),
+	}
+	credential, err := resolver.Resolve(ctx, credentialConfig)
+	apiKey := credential.APIKey
+
 	// Handle missing values
-	if apiKey == "" {
+	switch {
+	case errors.Is(err, credentials.ErrNoCredentials):
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing Armis API Key",
 			"The provider cannot create the Armis API client as there is a missing API key. "+
-				"Set this value in the configuration or use the ARMIS_API_KEY environment variable.",
+				"Set api_key, api_key_file or credential_process in the configuration, "+
+				"or use the ARMIS_API_KEY or ARMIS_API_KEY_FILE environment variables.",
+		)
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(
+			credentialAttributePath(config),
+			"Unable to Read Armis API Key",
+			"The provider cannot create the Armis API client as the API key could not be read: "+err.Error(),
 		)
 	}
 
@@ -131,29 +247,79 @@ func (p *ArmisProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	readOnly := readOnlyFromConfig(config.ReadOnly, os.Getenv, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	transportConfig := transport.DefaultConfig()
+	transportConfig.Retry = retryConfigFromModel(config.Retry, &resp.Diagnostics)
+	transportConfigFromModel(&transportConfig, config.Transport, os.Getenv, &resp.Diagnostics)
+	transportConfig.MaxConcurrentRequests = maxConcurrentRequestsFromConfig(config.MaxConcurrentRequests, os.Getenv, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpClient, err := transport.NewHTTPClient(transportConfig)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("transport"),
+			"Invalid Transport Configuration",
+			"The provider cannot build the HTTP client for the Armis API: "+err.Error(),
+		)
+		return
+	}
+
 	// Debug log the configuration
 	ctx = tflog.SetField(ctx, "armis_api_url", apiURL)
 	ctx = tflog.SetField(ctx, "armis_api_key", apiKey)
+	ctx = tflog.SetField(ctx, "armis_api_key_source", credential.Source.String())
+	ctx = tflog.SetField(ctx, "armis_retry_max_attempts", transportConfig.Retry.MaxAttempts)
+	ctx = tflog.SetField(ctx, "armis_max_concurrent_requests", transportConfig.MaxConcurrentRequests)
+	ctx = tflog.SetField(ctx, "armis_proxy_url", transportConfig.ProxyURL)
+	ctx = tflog.SetField(ctx, "armis_insecure_skip_verify", transportConfig.TLS.InsecureSkipVerify)
+	ctx = tflog.SetField(ctx, "armis_read_only", readOnly)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "armis_api_key")
 
 	tflog.Debug(ctx, "Creating the Armis API client")
 
-	// Create the Armis client
+	// Create the Armis client. Every request made through it goes through
+	// the provider's HTTP transport, which applies the TLS, proxy and retry
+	// settings.
 	client, err := armis.NewClient(
 		apiKey,
 		apiURL,
+		armis.WithHTTPClient(httpClient),
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Armis API Client",
-			"An error occurred when creating the Armis API client: "+err.Error(),
+			fmt.Sprintf("An error occurred when creating the Armis API client with the API key from the %s: %s",
+				credential.Source, err),
 		)
 		return
 	}
 
-	// Make the client available to data sources and resources
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	lookups := cache.New(client, !config.DisableLookupCache.ValueBool())
+
+	if !config.SkipCredentialsValidation.ValueBool() {
+		validateCredentials(ctx, lookups, config, credential, apiURL, &resp.Diagnostics, func() {
+			resolver.Forget(credentialConfig)
+		})
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Make the client and cache available to data sources, resources and ephemeral resources
+	data := &ArmisProviderData{
+		Client:   client,
+		Cache:    lookups,
+		ReadOnly: readOnly,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 
 	tflog.Info(ctx, "Armis API client created", map[string]any{"success": true})
 }
@@ -165,6 +331,17 @@ func (p *ArmisProvider) Resources(ctx context.Context) []func() resource.Resourc
 		CollectorResource,
 		PolicyResource,
 		ReportResource,
+		SiteResource,
+		BoundaryResource,
+		ListResource,
+		ListEntriesResource,
+		DeviceTagsResource,
+	}
+}
+
+func (p *ArmisProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		CollectorCredentialsEphemeralResource,
 	}
 }
 
@@ -179,6 +356,12 @@ func (p *ArmisProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		ListsDataSource,
 		ReportsDataSource,
 		TagsDataSource,
+		DevicesDataSource,
+		AlertsDataSource,
+		VulnerabilitiesDataSource,
+		SearchDataSource,
+		MitreAttackDataSource,
+		MitreCoverageDataSource,
 	}
 }
 
@@ -0,0 +1,292 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// retryModel maps the provider retry block.
+type retryModel struct {
+	MaxAttempts       types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff        types.String `tfsdk:"min_backoff"`
+	MaxBackoff        types.String `tfsdk:"max_backoff"`
+	Jitter            types.Bool   `tfsdk:"jitter"`
+	RespectRetryAfter types.Bool   `tfsdk:"respect_retry_after"`
+}
+
+func retryBlockSchema() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Retry policy applied to every Armis API request. Requests are retried on HTTP 429 and 503 responses " +
+			"and when no connection could be made; idempotent requests (GET, PUT, DELETE) are also retried on HTTP 500, 502 and 504 " +
+			"and on other network errors.",
+		Attributes: map[string]schema.Attribute{
+			"max_attempts": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Total number of attempts for a request, including the first one. "+
+					"Set to `1` to disable retries. Defaults to `%d`.", transport.DefaultMaxAttempts),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 20),
+				},
+			},
+			"min_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Wait before the first retry, as a duration string. "+
+					"Each following wait doubles. Defaults to `%s`.", transport.DefaultMinBackoff),
+				Optional: true,
+				Validators: []validator.String{
+					verify.ValidDuration(),
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Upper bound for the wait between retries, as a duration string. "+
+					"Defaults to `%s`.", transport.DefaultMaxBackoff),
+				Optional: true,
+				Validators: []validator.String{
+					verify.ValidDuration(),
+				},
+			},
+			"jitter": schema.BoolAttribute{
+				MarkdownDescription: "Randomize each wait between half and all of the computed backoff. Defaults to `true`.",
+				Optional:            true,
+			},
+			"respect_retry_after": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the duration given by the `Retry-After` response header, when present, " +
+					"instead of the computed backoff. When it asks for longer than `max_backoff`, the request fails instead of waiting. Defaults to `true`.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// retryConfigFromModel overlays the configured retry block on the default
+// retry policy.
+func retryConfigFromModel(model *retryModel, diags *diag.Diagnostics) transport.RetryConfig {
+	config := transport.DefaultRetryConfig()
+	if model == nil {
+		return config
+	}
+
+	if !model.MaxAttempts.IsNull() && !model.MaxAttempts.IsUnknown() {
+		config.MaxAttempts = int(model.MaxAttempts.ValueInt64())
+	}
+	if d, ok := durationValue(model.MinBackoff); ok {
+		config.MinBackoff = d
+	}
+	if d, ok := durationValue(model.MaxBackoff); ok {
+		config.MaxBackoff = d
+	}
+	if !model.Jitter.IsNull() && !model.Jitter.IsUnknown() {
+		config.Jitter = model.Jitter.ValueBool()
+	}
+	if !model.RespectRetryAfter.IsNull() && !model.RespectRetryAfter.IsUnknown() {
+		config.RespectRetryAfter = model.RespectRetryAfter.ValueBool()
+	}
+
+	if config.MinBackoff > config.MaxBackoff {
+		diags.AddAttributeError(
+			path.Root("retry").AtName("min_backoff"),
+			"Invalid Retry Configuration",
+			fmt.Sprintf("min_backoff (%s) must not be greater than max_backoff (%s).", config.MinBackoff, config.MaxBackoff),
+		)
+	}
+
+	return config
+}
+
+// durationValue parses a duration attribute. Invalid values are rejected by
+// the schema validators, so they are treated as unset here.
+func durationValue(value types.String) (time.Duration, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return 0, false
+	}
+
+	return d, true
+}
+
+// credentialPrecedenceDescription documents the order in which API key
+// sources are consulted.
+const credentialPrecedenceDescription = "The API key is taken from the first of these sources that is set: " +
+	"`api_key`, `api_key_file`, `credential_process`, the `ARMIS_API_KEY` environment variable and " +
+	"the `ARMIS_API_KEY_FILE` environment variable."
+
+// credentialAttributePath returns the attribute to attach credential errors
+// to: the configured credential argument, or api_key when the key comes from
+// the environment.
+func credentialAttributePath(config ArmisProviderModel) path.Path {
+	switch {
+	case !config.APIKeyFile.IsNull():
+		return path.Root("api_key_file")
+	case !config.CredentialProcess.IsNull():
+		return path.Root("credential_process")
+	default:
+		return path.Root("api_key")
+	}
+}
+
+// Environment variables backing the transport block.
+const (
+	envCABundleFile          = "ARMIS_CA_BUNDLE_FILE"
+	envClientCertificateFile = "ARMIS_CLIENT_CERTIFICATE_FILE"
+	envClientKeyFile         = "ARMIS_CLIENT_KEY_FILE"
+	envInsecureSkipVerify    = "ARMIS_INSECURE_SKIP_VERIFY"
+	envProxyURL              = "ARMIS_PROXY_URL"
+)
+
+// transportModel maps the provider transport block.
+type transportModel struct {
+	CABundleFile          types.String `tfsdk:"ca_bundle_file"`
+	ClientCertificateFile types.String `tfsdk:"client_certificate_file"`
+	ClientKeyFile         types.String `tfsdk:"client_key_file"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL              types.String `tfsdk:"proxy_url"`
+}
+
+func transportBlockSchema() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "TLS and proxy settings of the HTTP client used to reach the Armis API. " +
+			"Every attribute can also be set with the environment variable named in its description.",
+		Attributes: map[string]schema.Attribute{
+			"ca_bundle_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file of certificate authorities to trust in addition to the system roots, " +
+					"for example the root certificate of a TLS-inspecting proxy. Environment variable: `" + envCABundleFile + "`.",
+				Optional: true,
+			},
+			"client_certificate_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded client certificate presented to servers that require mutual TLS. " +
+					"Requires `client_key_file`. Environment variable: `" + envClientCertificateFile + "`.",
+				Optional: true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the PEM encoded private key of `client_certificate_file`. " +
+					"Environment variable: `" + envClientKeyFile + "`.",
+				Optional: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the Armis API server certificate. Only intended for lab tenants " +
+					"with self-signed certificates. Defaults to `false`. Environment variable: `" + envInsecureSkipVerify + "`.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy to send requests through, using the `http`, `https` or `socks5` scheme. " +
+					"When unset, the standard `HTTPS_PROXY` and `NO_PROXY` environment variables apply. " +
+					"Environment variable: `" + envProxyURL + "`.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// transportConfigFromModel overlays the configured transport block, falling
+// back to the matching environment variables, on config.
+func transportConfigFromModel(config *transport.Config, model *transportModel, getenv func(string) string, diags *diag.Diagnostics) {
+	if model == nil {
+		model = &transportModel{}
+	}
+
+	config.TLS.CABundleFile = stringOrEnv(model.CABundleFile, getenv(envCABundleFile))
+	config.TLS.ClientCertificateFile = stringOrEnv(model.ClientCertificateFile, getenv(envClientCertificateFile))
+	config.TLS.ClientKeyFile = stringOrEnv(model.ClientKeyFile, getenv(envClientKeyFile))
+	config.ProxyURL = stringOrEnv(model.ProxyURL, getenv(envProxyURL))
+
+	switch {
+	case !model.InsecureSkipVerify.IsNull() && !model.InsecureSkipVerify.IsUnknown():
+		config.TLS.InsecureSkipVerify = model.InsecureSkipVerify.ValueBool()
+	case getenv(envInsecureSkipVerify) != "":
+		insecure, err := strconv.ParseBool(getenv(envInsecureSkipVerify))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("transport").AtName("insecure_skip_verify"),
+				"Invalid Transport Configuration",
+				fmt.Sprintf("The %s environment variable must be a boolean, got %q.", envInsecureSkipVerify, getenv(envInsecureSkipVerify)),
+			)
+		}
+		config.TLS.InsecureSkipVerify = insecure
+	}
+
+	if (config.TLS.ClientCertificateFile == "") != (config.TLS.ClientKeyFile == "") {
+		missing := "client_key_file"
+		if config.TLS.ClientCertificateFile == "" {
+			missing = "client_certificate_file"
+		}
+		diags.AddAttributeError(
+			path.Root("transport").AtName(missing),
+			"Invalid Transport Configuration",
+			"client_certificate_file and client_key_file must be set together.",
+		)
+	}
+
+	if config.ProxyURL != "" {
+		if _, err := transport.ParseProxyURL(config.ProxyURL); err != nil {
+			diags.AddAttributeError(
+				path.Root("transport").AtName("proxy_url"),
+				"Invalid Transport Configuration",
+				fmt.Sprintf("The proxy URL is not valid: %s", err),
+			)
+		}
+	}
+
+	if config.TLS.InsecureSkipVerify {
+		diags.AddAttributeWarning(
+			path.Root("transport").AtName("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The Armis API server certificate is not verified. Only use insecure_skip_verify with lab tenants.",
+		)
+	}
+}
+
+// stringOrEnv returns the configured value, or the environment value when
+// the attribute is not set.
+func stringOrEnv(value types.String, env string) string {
+	if value.IsNull() || value.IsUnknown() {
+		return env
+	}
+
+	return value.ValueString()
+}
+
+// envMaxConcurrentRequests is the environment variable backing the
+// max_concurrent_requests attribute.
+const envMaxConcurrentRequests = "ARMIS_MAX_CONCURRENT_REQUESTS"
+
+// maxConcurrentRequestsFromConfig returns the configured request limit,
+// falling back to the environment. Zero means no limit.
+func maxConcurrentRequestsFromConfig(value types.Int64, getenv func(string) string, diags *diag.Diagnostics) int {
+	if !value.IsNull() && !value.IsUnknown() {
+		return int(value.ValueInt64())
+	}
+
+	raw := getenv(envMaxConcurrentRequests)
+	if raw == "" {
+		return 0
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		diags.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Concurrency Limit",
+			fmt.Sprintf("The %s environment variable must be a positive integer, got %q.", envMaxConcurrentRequests, raw),
+		)
+		return 0
+	}
+
+	return limit
+}
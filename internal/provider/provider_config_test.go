@@ -0,0 +1,304 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestRetryConfigFromModel tests overlaying the retry block on the defaults.
+func TestRetryConfigFromModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		model       *retryModel
+		expected    transport.RetryConfig
+		expectError bool
+	}{
+		{
+			name:     "nil block uses defaults",
+			model:    nil,
+			expected: transport.DefaultRetryConfig(),
+		},
+		{
+			name: "empty block uses defaults",
+			model: &retryModel{
+				MaxAttempts:       types.Int64Null(),
+				MinBackoff:        types.StringNull(),
+				MaxBackoff:        types.StringNull(),
+				Jitter:            types.BoolNull(),
+				RespectRetryAfter: types.BoolNull(),
+			},
+			expected: transport.DefaultRetryConfig(),
+		},
+		{
+			name: "all values overridden",
+			model: &retryModel{
+				MaxAttempts:       types.Int64Value(7),
+				MinBackoff:        types.StringValue("250ms"),
+				MaxBackoff:        types.StringValue("2m"),
+				Jitter:            types.BoolValue(false),
+				RespectRetryAfter: types.BoolValue(false),
+			},
+			expected: transport.RetryConfig{
+				MaxAttempts:       7,
+				MinBackoff:        250 * time.Millisecond,
+				MaxBackoff:        2 * time.Minute,
+				Jitter:            false,
+				RespectRetryAfter: false,
+			},
+		},
+		{
+			name: "min backoff greater than max backoff",
+			model: &retryModel{
+				MaxAttempts:       types.Int64Null(),
+				MinBackoff:        types.StringValue("1m"),
+				MaxBackoff:        types.StringValue("10s"),
+				Jitter:            types.BoolNull(),
+				RespectRetryAfter: types.BoolNull(),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			got := retryConfigFromModel(tt.model, &diags)
+
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Fatal("Expected an error diagnostic, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestCredentialAttributePath tests which attribute credential errors are attached to.
+func TestCredentialAttributePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   ArmisProviderModel
+		expected path.Path
+	}{
+		{
+			name: "key from the environment",
+			config: ArmisProviderModel{
+				APIKey:            types.StringNull(),
+				APIKeyFile:        types.StringNull(),
+				CredentialProcess: types.StringNull(),
+			},
+			expected: path.Root("api_key"),
+		},
+		{
+			name: "key file",
+			config: ArmisProviderModel{
+				APIKey:            types.StringNull(),
+				APIKeyFile:        types.StringValue("/run/secrets/armis"),
+				CredentialProcess: types.StringNull(),
+			},
+			expected: path.Root("api_key_file"),
+		},
+		{
+			name: "credential process",
+			config: ArmisProviderModel{
+				APIKey:            types.StringNull(),
+				APIKeyFile:        types.StringNull(),
+				CredentialProcess: types.StringValue("armis-credentials"),
+			},
+			expected: path.Root("credential_process"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := credentialAttributePath(tt.config); !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestTransportConfigFromModel tests overlaying the transport block and its environment variables on the defaults.
+func TestTransportConfigFromModel(t *testing.T) {
+	t.Parallel()
+
+	emptyModel := &transportModel{
+		CABundleFile:          types.StringNull(),
+		ClientCertificateFile: types.StringNull(),
+		ClientKeyFile:         types.StringNull(),
+		InsecureSkipVerify:    types.BoolNull(),
+		ProxyURL:              types.StringNull(),
+	}
+
+	tests := []struct {
+		name           string
+		model          *transportModel
+		env            map[string]string
+		expectedTLS    transport.TLSConfig
+		expectedProxy  string
+		expectError    bool
+		expectWarnings int
+	}{
+		{
+			name:  "nil block and no environment",
+			model: nil,
+		},
+		{
+			name:  "environment fallback",
+			model: emptyModel,
+			env: map[string]string{
+				"ARMIS_CA_BUNDLE_FILE":          "/etc/armis/ca.pem",
+				"ARMIS_CLIENT_CERTIFICATE_FILE": "/etc/armis/client.pem",
+				"ARMIS_CLIENT_KEY_FILE":         "/etc/armis/client-key.pem",
+				"ARMIS_PROXY_URL":               "http://proxy.example.com:3128",
+			},
+			expectedTLS: transport.TLSConfig{
+				CABundleFile:          "/etc/armis/ca.pem",
+				ClientCertificateFile: "/etc/armis/client.pem",
+				ClientKeyFile:         "/etc/armis/client-key.pem",
+			},
+			expectedProxy: "http://proxy.example.com:3128",
+		},
+		{
+			name: "block overrides environment",
+			model: &transportModel{
+				CABundleFile:          types.StringValue("/config/ca.pem"),
+				ClientCertificateFile: types.StringNull(),
+				ClientKeyFile:         types.StringNull(),
+				InsecureSkipVerify:    types.BoolValue(false),
+				ProxyURL:              types.StringValue("socks5://127.0.0.1:1080"),
+			},
+			env: map[string]string{
+				"ARMIS_CA_BUNDLE_FILE":       "/etc/armis/ca.pem",
+				"ARMIS_INSECURE_SKIP_VERIFY": "true",
+				"ARMIS_PROXY_URL":            "http://proxy.example.com:3128",
+			},
+			expectedTLS:   transport.TLSConfig{CABundleFile: "/config/ca.pem"},
+			expectedProxy: "socks5://127.0.0.1:1080",
+		},
+		{
+			name:           "insecure skip verify from the environment warns",
+			model:          nil,
+			env:            map[string]string{"ARMIS_INSECURE_SKIP_VERIFY": "1"},
+			expectedTLS:    transport.TLSConfig{InsecureSkipVerify: true},
+			expectWarnings: 1,
+		},
+		{
+			name:        "invalid insecure skip verify in the environment",
+			model:       nil,
+			env:         map[string]string{"ARMIS_INSECURE_SKIP_VERIFY": "maybe"},
+			expectError: true,
+		},
+		{
+			name: "client certificate without key",
+			model: &transportModel{
+				CABundleFile:          types.StringNull(),
+				ClientCertificateFile: types.StringValue("/etc/armis/client.pem"),
+				ClientKeyFile:         types.StringNull(),
+				InsecureSkipVerify:    types.BoolNull(),
+				ProxyURL:              types.StringNull(),
+			},
+			expectError: true,
+		},
+		{
+			name:        "invalid proxy URL",
+			model:       nil,
+			env:         map[string]string{"ARMIS_PROXY_URL": "ftp://proxy.example.com"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := transport.DefaultConfig()
+			var diags diag.Diagnostics
+			transportConfigFromModel(&config, tt.model, func(key string) string { return tt.env[key] }, &diags)
+
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Fatal("Expected an error diagnostic, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if got := diags.WarningsCount(); got != tt.expectWarnings {
+				t.Errorf("Expected %d warnings, got %d", tt.expectWarnings, got)
+			}
+			if config.TLS != tt.expectedTLS {
+				t.Errorf("Expected TLS %+v, got %+v", tt.expectedTLS, config.TLS)
+			}
+			if config.ProxyURL != tt.expectedProxy {
+				t.Errorf("Expected proxy %q, got %q", tt.expectedProxy, config.ProxyURL)
+			}
+		})
+	}
+}
+
+// TestMaxConcurrentRequestsFromConfig tests resolving the request limit from the attribute and its environment variable.
+func TestMaxConcurrentRequestsFromConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.Int64
+		env         string
+		expected    int
+		expectError bool
+	}{
+		{name: "unlimited by default", value: types.Int64Null(), expected: 0},
+		{name: "attribute", value: types.Int64Value(4), expected: 4},
+		{name: "attribute wins over the environment", value: types.Int64Value(4), env: "8", expected: 4},
+		{name: "environment", value: types.Int64Null(), env: "8", expected: 8},
+		{name: "environment not a number", value: types.Int64Null(), env: "many", expectError: true},
+		{name: "environment not positive", value: types.Int64Null(), env: "0", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			getenv := func(key string) string {
+				if key == "ARMIS_MAX_CONCURRENT_REQUESTS" {
+					return tt.env
+				}
+				return ""
+			}
+
+			var diags diag.Diagnostics
+			got := maxConcurrentRequestsFromConfig(tt.value, getenv, &diags)
+			if diags.HasError() != tt.expectError {
+				t.Fatalf("Expected error: %t, got diagnostics: %v", tt.expectError, diags)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ func requireEnv(t *testing.T, name string) {
 }
 
 func testAccPreCheck(t *testing.T) {
-	requireEnv(t, "ARMIS_API_KEY")
+	if os.Getenv("ARMIS_API_KEY_FILE") == "" {
+		requireEnv(t, "ARMIS_API_KEY")
+	}
 	requireEnv(t, "ARMIS_API_URL")
 }
@@ -0,0 +1,148 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/credentials"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// credentialsValidationTimeout bounds the connectivity probe made while the
+// provider is configured.
+const credentialsValidationTimeout = 30 * time.Second
+
+// validateCredentials makes a lightweight authenticated request so that an
+// unreachable tenant or a rejected API key is reported against the provider
+// configuration instead of the first resource that calls the API. The roles
+// are listed through the lookup cache so that the response is reused.
+// rejected is called when the API rejects the key, so that a cached key is
+// not handed out again.
+func validateCredentials(ctx context.Context, lookups *cache.Cache, config ArmisProviderModel, credential credentials.Credential, apiURL string, diags *diag.Diagnostics, rejected func()) {
+	ctx, cancel := context.WithTimeout(ctx, credentialsValidationTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Validating Armis API credentials")
+
+	_, err := lookups.Roles(ctx)
+	if err == nil {
+		return
+	}
+
+	if probeStatusCode(err) == http.StatusUnauthorized {
+		rejected()
+	}
+	appendCredentialsValidationError(diags, err, config, credential, apiURL)
+}
+
+// appendCredentialsValidationError classifies a failed connectivity probe as
+// an unreachable host, a TLS failure, a rejected API key or a missing
+// permission, and attaches it to the attribute the operator has to fix.
+func appendCredentialsValidationError(diags *diag.Diagnostics, err error, config ArmisProviderModel, credential credentials.Credential, apiURL string) {
+	const skipHint = "\n\nSet skip_credentials_validation = true to skip this check."
+
+	switch status := probeStatusCode(err); {
+	case status == http.StatusUnauthorized:
+		diags.AddAttributeError(
+			credentialAttributePath(config),
+			"Armis API Key Rejected",
+			fmt.Sprintf("The Armis API at %s rejected the API key from the %s (HTTP 401 Unauthorized). "+
+				"Check that the key is valid and has not been revoked.", apiURL, credential.Source),
+		)
+		return
+	case status == http.StatusForbidden:
+		diags.AddAttributeError(
+			credentialAttributePath(config),
+			"Armis API Key Not Permitted",
+			fmt.Sprintf("The Armis API at %s accepted the API key from the %s but denied access to the roles endpoint "+
+				"(HTTP 403 Forbidden). Grant the key's user a role that can read roles.", apiURL, credential.Source)+skipHint,
+		)
+		return
+	case status != 0:
+		diags.AddAttributeError(
+			path.Root("api_url"),
+			"Unable to Validate Armis API Credentials",
+			fmt.Sprintf("The Armis API at %s answered the credentials check with HTTP %d %s.",
+				apiURL, status, http.StatusText(status))+skipHint,
+		)
+		return
+	}
+
+	if isTLSError(err) {
+		diags.AddAttributeError(
+			path.Root("transport"),
+			"TLS Handshake With Armis API Failed",
+			fmt.Sprintf("The TLS connection to the Armis API at %s failed: %v\n\n"+
+				"If the tenant is reached through a TLS-inspecting proxy, trust its root certificate with "+
+				"transport.ca_bundle_file or the ARMIS_CA_BUNDLE_FILE environment variable.", apiURL, err),
+		)
+		return
+	}
+
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	if errors.As(err, &dnsErr) || errors.As(err, &opErr) || errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		diags.AddAttributeError(
+			path.Root("api_url"),
+			"Armis API Unreachable",
+			fmt.Sprintf("The Armis API at %s could not be reached: %v\n\n"+
+				"Check api_url and, if a proxy is required, transport.proxy_url.", apiURL, err)+skipHint,
+		)
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("api_url"),
+		"Unable to Validate Armis API Credentials",
+		fmt.Sprintf("The credentials check against the Armis API at %s failed: %v", apiURL, err)+skipHint,
+	)
+}
+
+// probeStatusCode returns the HTTP status of a failed API call, or zero when
+// no response was received.
+func probeStatusCode(err error) int {
+	var retryErr *transport.RetryError
+	if errors.As(err, &retryErr) && retryErr.StatusCode != 0 {
+		return retryErr.StatusCode
+	}
+
+	var apiErr *armis.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
+
+func isTLSError(err error) bool {
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	return errors.As(err, &certErr) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/credentials"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// probeError performs a GET against url with the provider's HTTP client and
+// returns the resulting error.
+func probeError(t *testing.T, url string) error {
+	t.Helper()
+
+	config := transport.DefaultConfig()
+	config.Retry.MaxAttempts = 1
+	client, err := transport.NewHTTPClient(config)
+	if err != nil {
+		t.Fatalf("building client: %v", err)
+	}
+
+	resp, err := client.Get(url)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected GET %s to fail", url)
+	}
+
+	return err
+}
+
+// closedPortURL returns the URL of a local port nothing listens on.
+func closedPortURL(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	return "http://" + addr
+}
+
+// TestAppendCredentialsValidationError tests the classification of failed connectivity probes.
+func TestAppendCredentialsValidationError(t *testing.T) {
+	t.Parallel()
+
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	t.Cleanup(tlsServer.Close)
+
+	envConfig := ArmisProviderModel{
+		APIKey:            types.StringNull(),
+		APIKeyFile:        types.StringNull(),
+		CredentialProcess: types.StringNull(),
+	}
+	fileConfig := ArmisProviderModel{
+		APIKey:            types.StringNull(),
+		APIKeyFile:        types.StringValue("/run/secrets/armis"),
+		CredentialProcess: types.StringNull(),
+	}
+	envCredential := credentials.Credential{APIKey: "s3cr3t", Source: credentials.Source{Name: "ARMIS_API_KEY environment variable"}}
+	fileCredential := credentials.Credential{APIKey: "s3cr3t", Source: credentials.Source{Name: "api_key_file provider argument", Location: "/run/secrets/armis"}}
+
+	tests := []struct {
+		name            string
+		err             error
+		config          ArmisProviderModel
+		credential      credentials.Credential
+		expectedPath    path.Path
+		expectedSummary string
+		expectedDetail  string
+	}{
+		{
+			name:            "unauthorized",
+			err:             fmt.Errorf("error making request: %w", &armis.APIError{StatusCode: http.StatusUnauthorized}),
+			config:          fileConfig,
+			credential:      fileCredential,
+			expectedPath:    path.Root("api_key_file"),
+			expectedSummary: "Armis API Key Rejected",
+			expectedDetail:  "api_key_file provider argument (/run/secrets/armis)",
+		},
+		{
+			name:            "forbidden",
+			err:             &armis.APIError{StatusCode: http.StatusForbidden},
+			config:          envConfig,
+			credential:      envCredential,
+			expectedPath:    path.Root("api_key"),
+			expectedSummary: "Armis API Key Not Permitted",
+			expectedDetail:  "ARMIS_API_KEY environment variable",
+		},
+		{
+			name:            "unavailable after retries",
+			err:             &transport.RetryError{Attempts: 4, StatusCode: http.StatusServiceUnavailable},
+			config:          envConfig,
+			credential:      envCredential,
+			expectedPath:    path.Root("api_url"),
+			expectedSummary: "Unable to Validate Armis API Credentials",
+			expectedDetail:  "HTTP 503 Service Unavailable",
+		},
+		{
+			name:            "untrusted certificate",
+			err:             probeError(t, tlsServer.URL),
+			config:          envConfig,
+			credential:      envCredential,
+			expectedPath:    path.Root("transport"),
+			expectedSummary: "TLS Handshake With Armis API Failed",
+			expectedDetail:  "ca_bundle_file",
+		},
+		{
+			name:            "connection refused",
+			err:             probeError(t, closedPortURL(t)),
+			config:          envConfig,
+			credential:      envCredential,
+			expectedPath:    path.Root("api_url"),
+			expectedSummary: "Armis API Unreachable",
+			expectedDetail:  "could not be reached",
+		},
+		{
+			name:            "unknown host",
+			err:             &net.DNSError{Err: "no such host", Name: "tenant.armis.invalid", IsNotFound: true},
+			config:          envConfig,
+			credential:      envCredential,
+			expectedPath:    path.Root("api_url"),
+			expectedSummary: "Armis API Unreachable",
+			expectedDetail:  "tenant.armis.invalid",
+		},
+		{
+			name:            "anything else",
+			err:             errors.New("unexpected response"), //nolint:err113 // test fixture
+			config:          envConfig,
+			credential:      envCredential,
+			expectedPath:    path.Root("api_url"),
+			expectedSummary: "Unable to Validate Armis API Credentials",
+			expectedDetail:  "unexpected response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			appendCredentialsValidationError(&diags, tt.err, tt.config, tt.credential, "https://tenant.armis.invalid")
+
+			if len(diags) != 1 {
+				t.Fatalf("Expected 1 diagnostic, got %d", len(diags))
+			}
+
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+			if !ok {
+				t.Fatalf("Expected an attribute diagnostic, got %T", diags[0])
+			}
+			if !withPath.Path().Equal(tt.expectedPath) {
+				t.Errorf("Expected path %s, got %s", tt.expectedPath, withPath.Path())
+			}
+			if diags[0].Summary() != tt.expectedSummary {
+				t.Errorf("Expected summary %q, got %q", tt.expectedSummary, diags[0].Summary())
+			}
+			if !strings.Contains(diags[0].Detail(), tt.expectedDetail) {
+				t.Errorf("Expected detail to contain %q, got: %s", tt.expectedDetail, diags[0].Detail())
+			}
+			if strings.Contains(diags[0].Detail(), tt.credential.APIKey) {
+				t.Errorf("Detail leaks the API key: %s", diags[0].Detail())
+			}
+		})
+	}
+}
+
+// statusLister fails every roles request with the given HTTP status.
+type statusLister struct {
+	cache.Lister
+	status int
+}
+
+func (l statusLister) GetRoles(context.Context) ([]armis.RoleSettings, error) {
+	return nil, &transport.RetryError{Attempts: 1, StatusCode: l.status}
+}
+
+// TestValidateCredentialsRejected tests that only a rejected API key is
+// reported as rejected.
+func TestValidateCredentialsRejected(t *testing.T) {
+	t.Parallel()
+
+	for status, expected := range map[int]bool{
+		http.StatusUnauthorized:        true,
+		http.StatusForbidden:           false,
+		http.StatusInternalServerError: false,
+	} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			rejected := false
+			lookups := cache.New(statusLister{status: status}, true)
+			validateCredentials(context.Background(), lookups, ArmisProviderModel{}, credentials.Credential{}, "https://tenant.armis.invalid", &diags, func() {
+				rejected = true
+			})
+
+			if !diags.HasError() {
+				t.Error("Expected an error")
+			}
+			if rejected != expected {
+				t.Errorf("Expected rejected %t, got %t", expected, rejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// envReadOnly is the environment variable backing the read_only attribute.
+const envReadOnly = "ARMIS_READ_ONLY"
+
+// appendReadOnlyError reports that a mutating operation was refused because
+// the provider is in read-only mode. Resources call it before making any
+// API request in Create, Update and Delete.
+func appendReadOnlyError(diags *diag.Diagnostics, operation, typeName string) {
+	diags.AddError(
+		"Provider Is Read-Only",
+		fmt.Sprintf("Cannot %s %s: the armis provider is configured with read_only = true or %s, "+
+			"so no changes are made to the Armis tenant. Data sources and refresh are not affected. "+
+			"Unset read_only to apply this change.", operation, typeName, envReadOnly),
+	)
+}
+
+// readOnlyFromConfig returns whether the provider runs in read-only mode. The
+// read_only attribute wins over the ARMIS_READ_ONLY environment variable.
+func readOnlyFromConfig(value types.Bool, getenv func(string) string, diags *diag.Diagnostics) bool {
+	if value.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown Read-Only Setting",
+			"The provider cannot decide whether it is read-only as there is an unknown configuration value for read_only. "+
+				"Set this value or use the "+envReadOnly+" environment variable.",
+		)
+		return false
+	}
+	if !value.IsNull() {
+		return value.ValueBool()
+	}
+
+	raw := strings.TrimSpace(getenv(envReadOnly))
+	if raw == "" {
+		return false
+	}
+
+	readOnly, err := strconv.ParseBool(raw)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("read_only"),
+			"Invalid Read-Only Setting",
+			fmt.Sprintf("The %s environment variable must be a boolean such as true or false, got %q.", envReadOnly, raw),
+		)
+		return false
+	}
+
+	return readOnly
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReadOnlyFromConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.Bool
+		env         string
+		expected    bool
+		expectError bool
+	}{
+		{name: "unset", value: types.BoolNull(), expected: false},
+		{name: "attribute", value: types.BoolValue(true), expected: true},
+		{name: "attribute wins over the environment", value: types.BoolValue(false), env: "true", expected: false},
+		{name: "environment", value: types.BoolNull(), env: "1", expected: true},
+		{name: "environment false", value: types.BoolNull(), env: "false", expected: false},
+		{name: "invalid environment", value: types.BoolNull(), env: "yes please", expectError: true},
+		{name: "unknown attribute", value: types.BoolUnknown(), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			getenv := func(key string) string {
+				if key == envReadOnly {
+					return tt.env
+				}
+				return ""
+			}
+
+			var diags diag.Diagnostics
+			got := readOnlyFromConfig(tt.value, getenv, &diags)
+			if diags.HasError() != tt.expectError {
+				t.Fatalf("Expected error: %t, got diagnostics: %v", tt.expectError, diags)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestReadOnlyResources tests that mutating operations are refused before the
+// API client, which is nil here, is used.
+func TestReadOnlyResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, newResource := range (&ArmisProvider{}).Resources(ctx) {
+		r := newResource()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "armis"}, &metadata)
+
+		t.Run(metadata.TypeName, func(t *testing.T) {
+			t.Parallel()
+
+			configurable, ok := r.(resource.ResourceWithConfigure)
+			if !ok {
+				t.Fatalf("%s does not implement resource.ResourceWithConfigure", metadata.TypeName)
+			}
+
+			var configure resource.ConfigureResponse
+			configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: &ArmisProviderData{ReadOnly: true}}, &configure)
+			if configure.Diagnostics.HasError() {
+				t.Fatalf("Unexpected configure error: %v", configure.Diagnostics)
+			}
+
+			var create resource.CreateResponse
+			r.Create(ctx, resource.CreateRequest{}, &create)
+			var update resource.UpdateResponse
+			r.Update(ctx, resource.UpdateRequest{}, &update)
+			var remove resource.DeleteResponse
+			r.Delete(ctx, resource.DeleteRequest{}, &remove)
+
+			for operation, diags := range map[string]diag.Diagnostics{
+				"create": create.Diagnostics,
+				"update": update.Diagnostics,
+				"delete": remove.Diagnostics,
+			} {
+				if len(diags) != 1 || diags[0].Summary() != "Provider Is Read-Only" {
+					t.Errorf("Expected %s to be refused, got %v", operation, diags)
+					continue
+				}
+				if !strings.Contains(diags[0].Detail(), "Cannot "+operation+" "+metadata.TypeName) {
+					t.Errorf("Expected %s detail to name the operation and type, got: %s", operation, diags[0].Detail())
+				}
+			}
+		})
+	}
+}
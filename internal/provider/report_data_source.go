@@ -9,6 +9,7 @@ import (
 	"math/big"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -30,17 +31,18 @@ func (d *reportsDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
+	d.cache = data.Cache
 }
 
 // ReportsDataSource is a helper function to simplify the provider implementation.
@@ -51,6 +53,7 @@ func ReportsDataSource() datasource.DataSource {
 // reportsDataSource is the data source implementation.
 type reportsDataSource struct {
 	client *armis.Client
+	cache  *cache.Cache
 }
 
 // Metadata returns the data source type name.
@@ -212,10 +215,7 @@ func (d *reportsDataSource) fetchReportByID(ctx context.Context, reportID string
 
 	report, err := d.client.GetReportByID(ctx, reportID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Armis Report",
-			fmt.Sprintf("Failed to fetch report with ID '%s': %s", reportID, err.Error()),
-		)
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Unable to Read Armis Report %q", reportID), err)
 		return nil
 	}
 
@@ -242,12 +242,9 @@ func (d *reportsDataSource) fetchReportByID(ctx context.Context, reportID string
 func (d *reportsDataSource) fetchAndFilterReports(ctx context.Context, reportName types.String, resp *datasource.ReadResponse) []reportModel {
 	tflog.Debug(ctx, "Fetching all reports")
 
-	allReports, err := d.client.GetReports(ctx)
+	allReports, err := d.cache.Reports(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Armis Reports",
-			fmt.Sprintf("Failed to fetch reports from Armis API: %s", err.Error()),
-		)
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Reports", err)
 		return nil
 	}
 
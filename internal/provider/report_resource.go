@@ -11,7 +11,11 @@ import (
 	"strconv"
 
 	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/asqtypes"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -31,7 +35,9 @@ var (
 )
 
 type reportResource struct {
-	client *armis.Client
+	client   *armis.Client
+	readOnly bool
+	cache    *cache.Cache
 }
 
 func ReportResource() resource.Resource {
@@ -44,16 +50,18 @@ func (r *reportResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+	r.cache = data.Cache
 }
 
 // Metadata returns the resource type name.
@@ -62,7 +70,7 @@ func (r *reportResource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 // Schema defines the schema for the report resource.
-func (r *reportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *reportResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: `
 Provides an Armis report resource.
@@ -84,7 +92,11 @@ The resource provisions a report in Armis with support for ASQ queries, scheduli
 			},
 			"asq": schema.StringAttribute{
 				Required:    true,
-				Description: "The Armis Standard Query (ASQ) for the report. Example: 'in:devices timeFrame:\"1 Day\"'",
+				CustomType:  asqtypes.QueryType{},
+				Description: "The Armis Standard Query (ASQ) for the report. Differences in spacing, quote style and clause order are not treated as changes. Example: 'in:devices timeFrame:\"1 Day\"'",
+				Validators: []validator.String{
+					verify.ValidASQ(),
+				},
 			},
 			"email_subject": schema.StringAttribute{
 				Optional:    true,
@@ -169,6 +181,9 @@ The resource provisions a report in Armis with support for ASQ queries, scheduli
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -176,12 +191,13 @@ The resource provisions a report in Armis with support for ASQ queries, scheduli
 type reportResourceModel struct {
 	ID                  types.String                    `tfsdk:"id"`
 	ReportName          types.String                    `tfsdk:"report_name"`
-	ASQ                 types.String                    `tfsdk:"asq"`
+	ASQ                 asqtypes.Query                  `tfsdk:"asq"`
 	EmailSubject        types.String                    `tfsdk:"email_subject"`
 	CreationTime        types.String                    `tfsdk:"creation_time"`
 	IsScheduled         types.Bool                      `tfsdk:"is_scheduled"`
 	Schedule            *reportScheduleModel            `tfsdk:"schedule"`
 	ExportConfiguration *reportExportConfigurationModel `tfsdk:"export_configuration"`
+	Timeouts            timeouts.Value                  `tfsdk:"timeouts"`
 }
 
 type reportScheduleModel struct {
@@ -206,6 +222,13 @@ type reportExportColumnsModel struct {
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *reportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_report")
+		return
+	}
+
+	defer r.cache.InvalidateReports()
+
 	var plan reportResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -214,6 +237,15 @@ func (r *reportResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	report := buildArmisReport(plan)
 	tflog.Info(ctx, "Creating report in Armis", map[string]any{"report_name": plan.ReportName.ValueString()})
 
@@ -265,6 +297,15 @@ func (r *reportResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Reading report from Armis", map[string]any{"report_id": state.ID.ValueString()})
 
 	report, err := r.client.GetReportByID(ctx, state.ID.ValueString())
@@ -293,7 +334,7 @@ func (r *reportResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// Update state with API response
 	state.ID = types.StringValue(strconv.Itoa(report.ID))
 	state.ReportName = types.StringValue(report.ReportName)
-	state.ASQ = types.StringValue(report.Asq)
+	state.ASQ = asqtypes.NewQueryValue(report.Asq)
 	state.CreationTime = types.StringValue(report.CreationTime)
 	state.IsScheduled = types.BoolValue(report.IsScheduled)
 
@@ -343,6 +384,13 @@ func (r *reportResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 // Update updates an existing report in Armis.
 func (r *reportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_report")
+		return
+	}
+
+	defer r.cache.InvalidateReports()
+
 	var plan reportResourceModel
 	var state reportResourceModel
 
@@ -358,6 +406,15 @@ func (r *reportResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	reportID := state.ID.ValueString()
 	updateReq := buildUpdateReportRequest(plan)
 
@@ -403,6 +460,13 @@ func (r *reportResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete deletes the resource.
 func (r *reportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_report")
+		return
+	}
+
+	defer r.cache.InvalidateReports()
+
 	var state reportResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -411,6 +475,15 @@ func (r *reportResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Deleting report from Armis", map[string]any{"report_id": state.ID.ValueString()})
 
 	success, err := r.client.DeleteReport(ctx, state.ID.ValueString())
@@ -0,0 +1,36 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Default per-operation deadlines used when a resource does not configure a
+// timeouts block. They cover every API call made by the operation, including
+// retries.
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+// timeoutsBlock returns the timeouts block shared by every managed resource.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: "Time to wait for the resource to be created, as a duration string such as `30s` or `2h45m`. Defaults to `10m`.",
+		ReadDescription:   "Time to wait for the resource to be read during refresh, as a duration string. Defaults to `5m`.",
+		UpdateDescription: "Time to wait for the resource to be updated, as a duration string. Defaults to `10m`.",
+		DeleteDescription: "Time to wait for the resource to be deleted, as a duration string. Defaults to `10m`.",
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 	u "github.com/1898andCo/terraform-provider-armis-centrix/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -30,7 +31,9 @@ var (
 )
 
 type roleResource struct {
-	client *armis.Client
+	client   *armis.Client
+	readOnly bool
+	cache    *cache.Cache
 }
 
 func RoleResource() resource.Resource {
@@ -43,16 +46,18 @@ func (r *roleResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+	r.cache = data.Cache
 }
 
 // Metadata returns the resource type name.
@@ -729,6 +734,9 @@ func (r *roleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -739,6 +747,13 @@ func (r *roleResource) ImportState(ctx context.Context, req resource.ImportState
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_role")
+		return
+	}
+
+	defer r.cache.InvalidateRoles()
+
 	var plan u.RoleResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -747,6 +762,15 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Creating role", map[string]any{"name": plan.Name.ValueString()})
 
 	if plan.Permissions == nil {
@@ -819,6 +843,15 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Fetch the role by ID
 	tflog.Debug(ctx, "Fetching role by ID", map[string]any{"role_id": state.ID.ValueString()})
 	role, err := r.client.GetRoleByID(ctx, state.ID.ValueString())
@@ -842,6 +875,13 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the role.
 func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_role")
+		return
+	}
+
+	defer r.cache.InvalidateRoles()
+
 	tflog.Info(ctx, "Updating role")
 
 	// Retrieve values from the plan
@@ -860,6 +900,15 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Validate that the role ID is available
 	if state.ID.IsNull() || state.ID.ValueString() == "" {
 		resp.Diagnostics.AddError(
@@ -910,6 +959,13 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 // Delete deletes the role.
 func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_role")
+		return
+	}
+
+	defer r.cache.InvalidateRoles()
+
 	var state u.RoleResourceModel
 	tflog.Info(ctx, "Deleting role")
 
@@ -919,6 +975,15 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the role in the API
 	tflog.Debug(ctx, "Deleting role in Armis", map[string]any{"role_id": state.ID.ValueString()})
 	success, err := r.client.DeleteRole(ctx, state.ID.ValueString())
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 	u "github.com/1898andCo/terraform-provider-armis-centrix/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -23,6 +24,7 @@ var (
 // rolesDataSource is the data source implementation.
 type rolesDataSource struct {
 	client *armis.Client
+	cache  *cache.Cache
 }
 
 // Configure adds the provider configured client to the data source.
@@ -31,16 +33,17 @@ func (d *rolesDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
+	d.cache = data.Cache
 }
 
 // RoleDataSource is a helper function to simplify the provider implementation.
@@ -768,10 +771,7 @@ func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		excludePrefix := config.ExcludePrefix
 		role, err := d.client.GetRoleByName(ctx, config.Name.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis Role",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis Role", err)
 			return
 		}
 
@@ -783,12 +783,9 @@ func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			config.ExcludePrefix = excludePrefix
 		}
 	} else {
-		allRoles, err := d.client.GetRoles(ctx)
+		allRoles, err := d.cache.Roles(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis Roles",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis Roles", err)
 			return
 		}
 
@@ -0,0 +1,139 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+)
+
+// searchPageSize is the number of results requested per search page.
+const searchPageSize = 100
+
+// searchPage is one page of search results.
+type searchPage[T any] struct {
+	Results []T
+	Next    int
+	Total   int
+}
+
+// searchAll requests pages from fetch until limit results are read, or every
+// result when limit is 0, and returns them together with the total number of
+// matches reported by the API.
+func searchAll[T any](ctx context.Context, limit int, fetch func(ctx context.Context, from, length int) (*searchPage[T], error)) ([]T, int, error) {
+	var results []T
+	total := 0
+	from := 0
+
+	for {
+		length := searchPageSize
+		if limit > 0 && limit-len(results) < length {
+			length = limit - len(results)
+		}
+
+		page, err := fetch(ctx, from, length)
+		if err != nil {
+			return nil, 0, err
+		}
+		if page == nil {
+			break
+		}
+
+		total = page.Total
+		results = append(results, page.Results...)
+
+		if len(page.Results) == 0 || page.Next <= from || len(results) >= total {
+			break
+		}
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+		from = page.Next
+	}
+
+	if total < len(results) {
+		total = len(results)
+	}
+
+	return results, total, nil
+}
+
+// deviceSearcher is the part of the Armis client used to search devices.
+type deviceSearcher interface {
+	SearchDevices(ctx context.Context, opts armis.SearchOptions) (*armis.DeviceSearchResults, error)
+}
+
+// searchDevices runs asq and returns up to limit matching devices, or every
+// matching device when limit is 0, together with the total number of matches
+// reported by the API. fields restricts the device fields returned.
+func searchDevices(ctx context.Context, client deviceSearcher, asq string, fields []string, limit int) ([]armis.DeviceSettings, int, error) {
+	return searchAll(ctx, limit, func(ctx context.Context, from, length int) (*searchPage[armis.DeviceSettings], error) {
+		page, err := client.SearchDevices(ctx, armis.SearchOptions{ASQ: asq, From: from, Length: length, Fields: fields})
+		if err != nil || page == nil {
+			return nil, err
+		}
+
+		return &searchPage[armis.DeviceSettings]{Results: page.Results, Next: page.Next, Total: page.Total}, nil
+	})
+}
+
+// alertSearcher is the part of the Armis client used to search alerts.
+type alertSearcher interface {
+	SearchAlerts(ctx context.Context, opts armis.SearchOptions) (*armis.AlertSearchResults, error)
+}
+
+// searchAlerts runs asq and returns up to limit matching alerts, or every
+// matching alert when limit is 0, together with the total number of matches
+// reported by the API.
+func searchAlerts(ctx context.Context, client alertSearcher, asq string, limit int) ([]armis.AlertSettings, int, error) {
+	return searchAll(ctx, limit, func(ctx context.Context, from, length int) (*searchPage[armis.AlertSettings], error) {
+		page, err := client.SearchAlerts(ctx, armis.SearchOptions{ASQ: asq, From: from, Length: length})
+		if err != nil || page == nil {
+			return nil, err
+		}
+
+		return &searchPage[armis.AlertSettings]{Results: page.Results, Next: page.Next, Total: page.Total}, nil
+	})
+}
+
+// vulnerabilitySearcher is the part of the Armis client used to search
+// vulnerabilities.
+type vulnerabilitySearcher interface {
+	SearchVulnerabilities(ctx context.Context, opts armis.SearchOptions) (*armis.VulnerabilitySearchResults, error)
+}
+
+// searchVulnerabilities runs asq and returns up to limit matching
+// vulnerabilities, or every matching vulnerability when limit is 0, together
+// with the total number of matches reported by the API.
+func searchVulnerabilities(ctx context.Context, client vulnerabilitySearcher, asq string, limit int) ([]armis.VulnerabilitySettings, int, error) {
+	return searchAll(ctx, limit, func(ctx context.Context, from, length int) (*searchPage[armis.VulnerabilitySettings], error) {
+		page, err := client.SearchVulnerabilities(ctx, armis.SearchOptions{ASQ: asq, From: from, Length: length})
+		if err != nil || page == nil {
+			return nil, err
+		}
+
+		return &searchPage[armis.VulnerabilitySettings]{Results: page.Results, Next: page.Next, Total: page.Total}, nil
+	})
+}
+
+// searcher is the part of the Armis client used to run arbitrary searches.
+type searcher interface {
+	Search(ctx context.Context, opts armis.SearchOptions) (*armis.SearchResults, error)
+}
+
+// searchRaw runs asq and returns up to limit results as raw JSON, or every
+// result when limit is 0, together with the total number of matches reported
+// by the API. fields restricts the fields returned.
+func searchRaw(ctx context.Context, client searcher, asq string, fields []string, limit int) ([]json.RawMessage, int, error) {
+	return searchAll(ctx, limit, func(ctx context.Context, from, length int) (*searchPage[json.RawMessage], error) {
+		page, err := client.Search(ctx, armis.SearchOptions{ASQ: asq, From: from, Length: length, Fields: fields})
+		if err != nil || page == nil {
+			return nil, err
+		}
+
+		return &searchPage[json.RawMessage]{Results: page.Results, Next: page.Next, Total: page.Total}, nil
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultSearchLimit is the number of results returned when limit is not set.
+const defaultSearchLimit = 1000
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &searchDataSource{}
+	_ datasource.DataSourceWithConfigure = &searchDataSource{}
+)
+
+// Configure adds the provider configured client to the data source.
+func (d *searchDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+// SearchDataSource is a helper function to simplify the provider implementation.
+func SearchDataSource() datasource.DataSource {
+	return &searchDataSource{}
+}
+
+// searchDataSource is the data source implementation.
+type searchDataSource struct {
+	client *armis.Client
+}
+
+// Metadata returns the data source type name.
+func (d *searchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+// Schema defines the schema for the search data source.
+func (d *searchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs an arbitrary ASQ query and returns the raw results as JSON. Use it with jsondecode for objects without a dedicated data source, such as activities, connections or applications.",
+		Attributes: map[string]schema.Attribute{
+			"asq": schema.StringAttribute{
+				Description: "The Armis Standard Query (ASQ) to run. Example: 'in:activity timeFrame:\"1 Days\"'",
+				Required:    true,
+				Validators: []validator.String{
+					verify.ValidASQ(),
+				},
+			},
+			"fields": schema.ListAttribute{
+				Description: "Optional list of fields to return for each result. All fields are returned when not set.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of results to return. Defaults to %d.", defaultSearchLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"result_count": schema.Int64Attribute{
+				Description: "The number of results returned.",
+				Computed:    true,
+			},
+			"total": schema.Int64Attribute{
+				Description: "The total number of results matching the query, which may exceed the number returned.",
+				Computed:    true,
+			},
+			"results_json": schema.StringAttribute{
+				Description: "The results as a JSON array, as returned by the API.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// searchDataSourceModel maps the data source schema data.
+type searchDataSourceModel struct {
+	ASQ         types.String   `tfsdk:"asq"`
+	Fields      []types.String `tfsdk:"fields"`
+	Limit       types.Int64    `tfsdk:"limit"`
+	ResultCount types.Int64    `tfsdk:"result_count"`
+	Total       types.Int64    `tfsdk:"total"`
+	ResultsJSON types.String   `tfsdk:"results_json"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *searchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state searchDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultSearchLimit
+	if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	// Page through the results
+	results, total, err := searchRaw(ctx, d.client, state.ASQ.ValueString(), typesStringSliceToStrings(state.Fields), limit)
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Unable to Run Armis Search", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetched search results from Armis API", map[string]any{
+		"asq":          state.ASQ.ValueString(),
+		"limit":        limit,
+		"total_count":  total,
+		"return_count": len(results),
+	})
+
+	if results == nil {
+		results = []json.RawMessage{}
+	}
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Encode Armis Search Results",
+			fmt.Sprintf("The search results could not be encoded as JSON: %s", err),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	state.ResultCount = types.Int64Value(int64(len(results)))
+	state.Total = types.Int64Value(int64(total))
+	state.ResultsJSON = types.StringValue(string(encoded))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
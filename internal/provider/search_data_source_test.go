@@ -0,0 +1,37 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_SearchDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSearchDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.armis_search.test", "total"),
+					resource.TestCheckResourceAttr("data.armis_search.test", "result_count", "5"),
+					resource.TestCheckResourceAttrSet("data.armis_search.test", "results_json"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSearchDataSourceConfig() string {
+	return `
+data "armis_search" "test" {
+  asq    = "in:devices timeFrame:\"7 Days\""
+  fields = ["id", "name"]
+  limit  = 5
+}
+`
+}
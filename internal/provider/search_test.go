@@ -0,0 +1,96 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+)
+
+// fakeDeviceSearcher serves total devices in pages, recording each request.
+type fakeDeviceSearcher struct {
+	total    int
+	err      error
+	requests []armis.SearchOptions
+}
+
+func (f *fakeDeviceSearcher) SearchDevices(_ context.Context, opts armis.SearchOptions) (*armis.DeviceSearchResults, error) {
+	f.requests = append(f.requests, opts)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	page := &armis.DeviceSearchResults{Total: f.total}
+	for id := opts.From + 1; id <= f.total && len(page.Results) < opts.Length; id++ {
+		page.Results = append(page.Results, armis.DeviceSettings{ID: id})
+	}
+	page.Count = len(page.Results)
+	if opts.From+page.Count < f.total {
+		page.Next = opts.From + page.Count
+	}
+
+	return page, nil
+}
+
+// TestSearchDevices tests paging through device search results.
+func TestSearchDevices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		total            int
+		limit            int
+		expectedDevices  int
+		expectedRequests int
+	}{
+		{name: "no matches", total: 0, expectedDevices: 0, expectedRequests: 1},
+		{name: "single page", total: 42, expectedDevices: 42, expectedRequests: 1},
+		{name: "exact page", total: searchPageSize, expectedDevices: searchPageSize, expectedRequests: 1},
+		{name: "several pages", total: 250, expectedDevices: 250, expectedRequests: 3},
+		{name: "limit within a page", total: 250, limit: 10, expectedDevices: 10, expectedRequests: 1},
+		{name: "limit across pages", total: 250, limit: 150, expectedDevices: 150, expectedRequests: 2},
+		{name: "limit above total", total: 20, limit: 50, expectedDevices: 20, expectedRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			searcher := &fakeDeviceSearcher{total: tt.total}
+			devices, total, err := searchDevices(context.Background(), searcher, "in:devices", []string{"id"}, tt.limit)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(devices) != tt.expectedDevices {
+				t.Errorf("Expected %d devices, got %d", tt.expectedDevices, len(devices))
+			}
+			if total != tt.total {
+				t.Errorf("Expected total %d, got %d", tt.total, total)
+			}
+			if len(searcher.requests) != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, len(searcher.requests))
+			}
+			for i, device := range devices {
+				if device.ID != i+1 {
+					t.Fatalf("Expected device %d to have ID %d, got %d", i, i+1, device.ID)
+				}
+			}
+		})
+	}
+}
+
+// TestSearchDevicesError tests that search errors are returned.
+func TestSearchDevicesError(t *testing.T) {
+	t.Parallel()
+
+	searchErr := errors.New("search failed") //nolint:err113 // test fixture
+	_, _, err := searchDevices(context.Background(), &fakeDeviceSearcher{err: searchErr}, "in:devices", nil, 0)
+	if !errors.Is(err, searchErr) {
+		t.Errorf("Expected %v, got %v", searchErr, err)
+	}
+}
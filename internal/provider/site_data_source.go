@@ -7,7 +7,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -28,17 +28,17 @@ func (d *sitesDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.cache = data.Cache
 }
 
 // SiteDataSource is a helper function to simplify the provider implementation.
@@ -48,7 +48,7 @@ func SiteDataSource() datasource.DataSource {
 
 // sitesDataSource is the data source implementation.
 type sitesDataSource struct {
-	client *armis.Client
+	cache *cache.Cache
 }
 
 // Metadata returns the data source type name.
@@ -121,12 +121,9 @@ type sitesModel struct {
 func (d *sitesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state sitesDataSourceModel
 
-	sites, err := d.client.GetSites(ctx)
+	sites, err := d.cache.Sites(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Armis Sites",
-			err.Error(),
-		)
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Sites", err)
 		return
 	}
 
@@ -0,0 +1,410 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &siteResource{}
+	_ resource.ResourceWithConfigure   = &siteResource{}
+	_ resource.ResourceWithImportState = &siteResource{}
+)
+
+type siteResource struct {
+	client   *armis.Client
+	readOnly bool
+	cache    *cache.Cache
+}
+
+func SiteResource() resource.Resource {
+	return &siteResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *siteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+	r.cache = data.Cache
+}
+
+// Metadata returns the resource type name.
+func (r *siteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site"
+}
+
+// Schema defines the schema for the site resource.
+func (r *siteResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+Provides an Armis site resource.
+
+Sites group the devices of a plant, office or other location. Their names are what ` + "`armis_user`" + ` role assignments refer to, so a site can be created in the same change that grants users access to it.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The unique identifier for the site.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the site.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"location": schema.StringAttribute{
+				Optional:    true,
+				Description: "The physical location or address of the site.",
+			},
+			"latitude": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The latitude coordinate of the site, between -90 and 90.",
+				Validators: []validator.Float64{
+					float64validator.Between(-90, 90),
+					float64validator.AlsoRequires(path.MatchRoot("longitude")),
+				},
+			},
+			"longitude": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The longitude coordinate of the site, between -180 and 180.",
+				Validators: []validator.Float64{
+					float64validator.Between(-180, 180),
+					float64validator.AlsoRequires(path.MatchRoot("latitude")),
+				},
+			},
+			"tier": schema.StringAttribute{
+				Optional:    true,
+				Description: "The tier classification of the site, which may represent its priority or categorization.",
+			},
+			"parent_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the parent site, for sites nested under a region or campus.",
+			},
+			"user": schema.StringAttribute{
+				Optional:    true,
+				Description: "The user who owns or is responsible for the site.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// siteResourceModel maps the resource schema data.
+type siteResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	Location  types.String   `tfsdk:"location"`
+	Latitude  types.Float64  `tfsdk:"latitude"`
+	Longitude types.Float64  `tfsdk:"longitude"`
+	Tier      types.String   `tfsdk:"tier"`
+	ParentID  types.String   `tfsdk:"parent_id"`
+	User      types.String   `tfsdk:"user"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *siteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_site")
+		return
+	}
+
+	defer r.cache.InvalidateSites()
+
+	var plan siteResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Creating site in Armis", map[string]any{"site_name": plan.Name.ValueString()})
+
+	site, err := r.client.CreateSite(ctx, buildArmisSite(plan))
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error creating site %q", plan.Name.ValueString()), err)
+		return
+	}
+	if site == nil || site.ID == "" {
+		resp.Diagnostics.AddError(
+			"Error Creating Armis Site",
+			fmt.Sprintf("Site %q was created but the API did not return its ID", plan.Name.ValueString()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Site created successfully", map[string]any{
+		"site_id":   site.ID,
+		"site_name": site.Name,
+	})
+
+	state := mapSiteToModel(site, plan)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read reads the resource state from the API.
+func (r *siteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state siteResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Reading site from Armis", map[string]any{"site_id": state.ID.ValueString()})
+
+	site, err := r.client.GetSiteByID(ctx, state.ID.ValueString())
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "Site not found, removing from state", map[string]any{
+				"site_id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error reading site %s", state.ID.ValueString()), err)
+		return
+	}
+
+	if site == nil {
+		tflog.Warn(ctx, "Site not found, removing from state", map[string]any{
+			"site_id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state = mapSiteToModel(site, state)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates an existing site in Armis.
+func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_site")
+		return
+	}
+
+	defer r.cache.InvalidateSites()
+
+	var plan siteResourceModel
+	var state siteResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	siteID := state.ID.ValueString()
+
+	tflog.Info(ctx, "Updating site in Armis", map[string]any{
+		"site_id":   siteID,
+		"site_name": plan.Name.ValueString(),
+	})
+
+	site, err := r.client.UpdateSite(ctx, siteID, buildArmisSite(plan))
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error updating site %s", siteID), err)
+		return
+	}
+	if site == nil {
+		resp.Diagnostics.AddError(
+			"Error Fetching Updated Site",
+			fmt.Sprintf("Site %s was updated but the API did not return it", siteID),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Site updated successfully", map[string]any{
+		"site_id":   siteID,
+		"site_name": site.Name,
+	})
+
+	plan.ID = state.ID
+	plan = mapSiteToModel(site, plan)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource.
+func (r *siteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_site")
+		return
+	}
+
+	defer r.cache.InvalidateSites()
+
+	var state siteResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Deleting site from Armis", map[string]any{"site_id": state.ID.ValueString()})
+
+	success, err := r.client.DeleteSite(ctx, state.ID.ValueString())
+	if err != nil {
+		var ae *armis.APIError
+		if errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, "Site already deleted", map[string]any{"site_id": state.ID.ValueString()})
+			return
+		}
+
+		appendAPIError(&resp.Diagnostics, fmt.Sprintf("Error deleting site %s", state.ID.ValueString()), err)
+		return
+	}
+
+	if !success {
+		resp.Diagnostics.AddError(
+			"Error Deleting Armis Site",
+			"Could not delete site: operation returned unsuccessful status",
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Site deleted successfully", map[string]any{"site_id": state.ID.ValueString()})
+}
+
+// ImportState imports an existing site into Terraform state.
+func (r *siteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// buildArmisSite converts the Terraform model to SDK site settings.
+func buildArmisSite(plan siteResourceModel) armis.SiteSettings {
+	return armis.SiteSettings{
+		Name:     plan.Name.ValueString(),
+		Location: plan.Location.ValueString(),
+		Lat:      plan.Latitude.ValueFloat64(),
+		Lng:      plan.Longitude.ValueFloat64(),
+		Tier:     plan.Tier.ValueString(),
+		ParentID: plan.ParentID.ValueString(),
+		User:     plan.User.ValueString(),
+	}
+}
+
+// mapSiteToModel overlays the site returned by the API on prior. The API
+// returns empty strings and zero coordinates for fields that were never set,
+// so those stay null when they are null in prior.
+func mapSiteToModel(site *armis.SiteSettings, prior siteResourceModel) siteResourceModel {
+	model := prior
+	model.ID = types.StringValue(site.ID)
+	model.Name = types.StringValue(site.Name)
+	model.Location = optionalStringValue(prior.Location, site.Location)
+	model.Tier = optionalStringValue(prior.Tier, site.Tier)
+	model.ParentID = optionalStringValue(prior.ParentID, site.ParentID)
+	model.User = optionalStringValue(prior.User, site.User)
+
+	if prior.Latitude.IsNull() && prior.Longitude.IsNull() && site.Lat == 0 && site.Lng == 0 {
+		model.Latitude = types.Float64Null()
+		model.Longitude = types.Float64Null()
+	} else {
+		model.Latitude = types.Float64Value(site.Lat)
+		model.Longitude = types.Float64Value(site.Lng)
+	}
+
+	return model
+}
+
+// optionalStringValue returns value, or null when the API left the field
+// empty and the attribute is not set in prior.
+func optionalStringValue(prior types.String, value string) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAcc_SiteResource tests creating, updating and importing a site.
+func TestAcc_SiteResource(t *testing.T) {
+	resourceName := "armis_site.test"
+	rName := strings.ToLower(acctest.RandomWithPrefix("tfacc-site"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteResourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckNoResourceAttr(resourceName, "latitude"),
+					resource.TestCheckNoResourceAttr(resourceName, "location"),
+				),
+			},
+			// Test updating the site in place
+			{
+				Config: testAccSiteResourceConfig_full(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "location", "Kansas City, MO"),
+					resource.TestCheckResourceAttr(resourceName, "latitude", "39.0997"),
+					resource.TestCheckResourceAttr(resourceName, "longitude", "-94.5786"),
+					resource.TestCheckResourceAttr(resourceName, "tier", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func testAccSiteResourceConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "armis_site" "test" {
+  name = %q
+}
+`, name)
+}
+
+func testAccSiteResourceConfig_full(name string) string {
+	return fmt.Sprintf(`
+resource "armis_site" "test" {
+  name      = %q
+  location  = "Kansas City, MO"
+  latitude  = 39.0997
+  longitude = -94.5786
+  tier      = "1"
+}
+`, name)
+}
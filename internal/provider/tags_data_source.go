@@ -8,7 +8,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -33,17 +33,17 @@ func (d *tagsDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.cache = data.Cache
 }
 
 // TagsDataSource is a helper function to simplify the provider implementation.
@@ -53,7 +53,7 @@ func TagsDataSource() datasource.DataSource {
 
 // tagsDataSource is the data source implementation.
 type tagsDataSource struct {
-	client *armis.Client
+	cache *cache.Cache
 }
 
 // Metadata returns the data source type name.
@@ -107,12 +107,9 @@ func (d *tagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	// Fetch all tags
-	apiTags, err := d.client.GetTags(ctx)
+	apiTags, err := d.cache.Tags(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Armis Tags",
-			err.Error(),
-		)
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Tags", err)
 		return
 	}
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -28,17 +29,18 @@ func (d *usersDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
+	d.cache = data.Cache
 }
 
 // UserDataSource is a helper function to simplify the provider implementation.
@@ -49,6 +51,7 @@ func UserDataSource() datasource.DataSource {
 // usersDataSource is the data source implementation.
 type usersDataSource struct {
 	client *armis.Client
+	cache  *cache.Cache
 }
 
 // Metadata returns the data source type name.
@@ -192,10 +195,7 @@ func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		// Fetch a specific user by email
 		user, err := d.client.GetUser(ctx, config.Email.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis User",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis User", err)
 			return
 		}
 
@@ -231,12 +231,9 @@ func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		users = append(users, userState)
 	} else {
 		// Fetch all users
-		allUsers, err := d.client.GetUsers(ctx)
+		allUsers, err := d.cache.Users(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Read Armis Users",
-				err.Error(),
-			)
+			appendAPIError(&resp.Diagnostics, "Unable to Read Armis Users", err)
 			return
 		}
 
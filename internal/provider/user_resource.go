@@ -12,7 +12,9 @@ import (
 	"strconv"
 
 	armis "github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/cache"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -32,7 +34,9 @@ var (
 )
 
 type userResource struct {
-	client *armis.Client
+	client   *armis.Client
+	readOnly bool
+	cache    *cache.Cache
 }
 
 func UserResource() resource.Resource {
@@ -45,16 +49,18 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*armis.Client)
+	data, ok := req.ProviderData.(*ArmisProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *armis.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+	r.cache = data.Cache
 }
 
 // Metadata returns the resource type name.
@@ -151,6 +157,9 @@ The resource provisions a user with the ability to define location, email, roles
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -164,6 +173,7 @@ type userResourceModel struct {
 	Title           types.String      `tfsdk:"title"`
 	Username        types.String      `tfsdk:"username"`
 	RoleAssignments []RoleAssignments `tfsdk:"role_assignments"`
+	Timeouts        timeouts.Value    `tfsdk:"timeouts"`
 }
 
 type RoleAssignments struct {
@@ -173,6 +183,13 @@ type RoleAssignments struct {
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "create", "armis_user")
+		return
+	}
+
+	defer r.cache.InvalidateUsers()
+
 	var plan userResourceModel
 
 	// Parse the plan from Terraform
@@ -182,6 +199,15 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	user := buildArmisUser(plan)
 	tflog.Info(ctx, "Creating user in Armis", map[string]any{"user": user})
 
@@ -236,6 +262,15 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed user value from Armis
 	user, err := r.client.GetUser(ctx, state.ID.ValueString())
 	if err != nil {
@@ -278,6 +313,13 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource.
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "update", "armis_user")
+		return
+	}
+
+	defer r.cache.InvalidateUsers()
+
 	// Retrieve values from plan
 	var plan userResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -294,6 +336,15 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Validate that the user ID is available
 	if state.ID.IsNull() || state.ID.ValueString() == "" {
 		resp.Diagnostics.AddError(
@@ -338,6 +389,13 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 // Delete deletes the resource.
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		appendReadOnlyError(&resp.Diagnostics, "delete", "armis_user")
+		return
+	}
+
+	defer r.cache.InvalidateUsers()
+
 	// Retrieve values from state
 	var state userResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -346,6 +404,15 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing user
 	success, err := r.client.DeleteUser(ctx, state.ID.ValueString())
 	if err != nil {
@@ -0,0 +1,215 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultVulnerabilityLimit is the number of vulnerabilities returned when
+// limit is not set.
+const defaultVulnerabilityLimit = 1000
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vulnerabilitiesDataSource{}
+	_ datasource.DataSourceWithConfigure = &vulnerabilitiesDataSource{}
+)
+
+// Configure adds the provider configured client to the data source.
+func (d *vulnerabilitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ArmisProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ArmisProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+// VulnerabilitiesDataSource is a helper function to simplify the provider implementation.
+func VulnerabilitiesDataSource() datasource.DataSource {
+	return &vulnerabilitiesDataSource{}
+}
+
+// vulnerabilitiesDataSource is the data source implementation.
+type vulnerabilitiesDataSource struct {
+	client *armis.Client
+}
+
+// Metadata returns the data source type name.
+func (d *vulnerabilitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vulnerabilities"
+}
+
+// Schema defines the schema for the vulnerabilities data source.
+func (d *vulnerabilitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves Armis vulnerabilities. Use asq or cve_ids to filter results.",
+		Attributes: map[string]schema.Attribute{
+			"asq": schema.StringAttribute{
+				Description: "Optional Armis Standard Query (ASQ) selecting the vulnerabilities. Example: 'in:vulnerabilities severity:Critical'",
+				Optional:    true,
+				Validators: []validator.String{
+					verify.ValidASQ("vulnerabilities"),
+					stringvalidator.ConflictsWith(path.MatchRoot("cve_ids")),
+				},
+			},
+			"cve_ids": schema.SetAttribute{
+				Description: "Optional filter to include only these CVEs, such as 'CVE-2021-44228'.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(cveIDPattern, "must be a CVE ID such as CVE-2021-44228")),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of vulnerabilities to return. Defaults to %d.", defaultVulnerabilityLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total": schema.Int64Attribute{
+				Description: "The total number of vulnerabilities matching the filters, which may exceed the number returned.",
+				Computed:    true,
+			},
+			"vulnerabilities": schema.ListNestedAttribute{
+				Description: "A computed list of vulnerabilities. Each object in the list contains detailed information about a vulnerability.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The CVE ID of the vulnerability.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the vulnerability.",
+							Computed:    true,
+						},
+						"severity": schema.StringAttribute{
+							Description: "The severity of the vulnerability.",
+							Computed:    true,
+						},
+						"cvss": schema.Float64Attribute{
+							Description: "The CVSS score of the vulnerability.",
+							Computed:    true,
+						},
+						"affected_device_count": schema.Int64Attribute{
+							Description: "The number of devices affected by the vulnerability.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the vulnerability.",
+							Computed:    true,
+						},
+						"published_date": schema.StringAttribute{
+							Description: "The date the vulnerability was published.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// vulnerabilitiesDataSourceModel maps the data source schema data.
+type vulnerabilitiesDataSourceModel struct {
+	ASQ             types.String         `tfsdk:"asq"`
+	CVEIDs          []types.String       `tfsdk:"cve_ids"`
+	Limit           types.Int64          `tfsdk:"limit"`
+	Total           types.Int64          `tfsdk:"total"`
+	Vulnerabilities []vulnerabilityModel `tfsdk:"vulnerabilities"`
+}
+
+// vulnerabilityModel maps the vulnerability schema data.
+type vulnerabilityModel struct {
+	ID                  types.String  `tfsdk:"id"`
+	Description         types.String  `tfsdk:"description"`
+	Severity            types.String  `tfsdk:"severity"`
+	CVSS                types.Float64 `tfsdk:"cvss"`
+	AffectedDeviceCount types.Int64   `tfsdk:"affected_device_count"`
+	Status              types.String  `tfsdk:"status"`
+	PublishedDate       types.String  `tfsdk:"published_date"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *vulnerabilitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state vulnerabilitiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultVulnerabilityLimit
+	if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	asq := state.ASQ.ValueString()
+	if state.ASQ.IsNull() {
+		asq = buildVulnerabilitiesASQ(typesStringSliceToStrings(state.CVEIDs))
+	}
+
+	// Page through the matching vulnerabilities
+	apiVulnerabilities, total, err := searchVulnerabilities(ctx, d.client, asq, limit)
+	if err != nil {
+		appendAPIError(&resp.Diagnostics, "Unable to Read Armis Vulnerabilities", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Fetched vulnerabilities from Armis API", map[string]any{
+		"asq":          asq,
+		"limit":        limit,
+		"total_count":  total,
+		"return_count": len(apiVulnerabilities),
+	})
+
+	// Map response body to model
+	vulnerabilities := make([]vulnerabilityModel, 0, len(apiVulnerabilities))
+	for _, v := range apiVulnerabilities {
+		vulnerabilities = append(vulnerabilities, vulnerabilityModel{
+			ID:                  types.StringValue(v.CveUID),
+			Description:         types.StringValue(v.Description),
+			Severity:            types.StringValue(v.Severity),
+			CVSS:                types.Float64Value(v.CvssScore),
+			AffectedDeviceCount: types.Int64Value(int64(v.AffectedDevicesCount)),
+			Status:              types.StringValue(v.Status),
+			PublishedDate:       types.StringValue(v.PublishedDate),
+		})
+	}
+
+	// Save data into Terraform state
+	state.Total = types.Int64Value(int64(total))
+	state.Vulnerabilities = vulnerabilities
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
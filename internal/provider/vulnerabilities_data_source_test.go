@@ -0,0 +1,50 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_VulnerabilitiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVulnerabilitiesDataSourceASQConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.armis_vulnerabilities.test", "total"),
+					resource.TestCheckResourceAttrSet("data.armis_vulnerabilities.test", "vulnerabilities.#"),
+				),
+			},
+			{
+				Config: testAccVulnerabilitiesDataSourceCVEConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.armis_vulnerabilities.cve", "cve_ids.#", "1"),
+					resource.TestCheckResourceAttrSet("data.armis_vulnerabilities.cve", "total"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVulnerabilitiesDataSourceASQConfig() string {
+	return `
+data "armis_vulnerabilities" "test" {
+  asq   = "in:vulnerabilities severity:Critical"
+  limit = 10
+}
+`
+}
+
+func testAccVulnerabilitiesDataSourceCVEConfig() string {
+	return `
+data "armis_vulnerabilities" "cve" {
+  cve_ids = ["CVE-2021-44228"]
+}
+`
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// SweepArmisBoundaries will delete all Armis boundaries with names starting with "tfacc".
+func SweepArmisBoundaries(name string) *resource.Sweeper {
+	return &resource.Sweeper{
+		Name: name,
+		F: func(_ string) error {
+			client, err := ConfigureSweeperClient(name)
+			if err != nil {
+				return fmt.Errorf("error configuring Armis client: %w", err)
+			}
+			if client == nil {
+				return nil
+			}
+
+			ctx := context.Background()
+			boundaries, err := client.GetBoundaries(ctx)
+			if err != nil {
+				return fmt.Errorf("error listing Armis boundaries: %w", err)
+			}
+
+			prefix := "tfacc"
+			for _, boundary := range boundaries {
+				if strings.HasPrefix(boundary.Name, prefix) {
+					log.Printf("[INFO] Deleting Armis boundary: %s", boundary.Name)
+					_, err := client.DeleteBoundary(ctx, strconv.Itoa(boundary.ID))
+					if err != nil {
+						log.Printf("[ERROR] Failed to delete Armis boundary %s: %s", boundary.Name, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// SweepArmisLists will delete all Armis lists with names starting with "tfacc".
+func SweepArmisLists(name string) *resource.Sweeper {
+	return &resource.Sweeper{
+		Name: name,
+		F: func(_ string) error {
+			client, err := ConfigureSweeperClient(name)
+			if err != nil {
+				return fmt.Errorf("error configuring Armis client: %w", err)
+			}
+			if client == nil {
+				return nil
+			}
+
+			ctx := context.Background()
+			lists, err := client.GetLists(ctx)
+			if err != nil {
+				return fmt.Errorf("error listing Armis lists: %w", err)
+			}
+
+			prefix := "tfacc"
+			for _, list := range lists {
+				if strings.HasPrefix(list.ListName, prefix) {
+					log.Printf("[INFO] Deleting Armis list: %s", list.ListName)
+					_, err := client.DeleteList(ctx, strconv.Itoa(list.ListID))
+					if err != nil {
+						log.Printf("[ERROR] Failed to delete Armis list %s: %s", list.ListName, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// SweepArmisSites will delete all Armis sites with names starting with "tfacc".
+func SweepArmisSites(name string) *resource.Sweeper {
+	return &resource.Sweeper{
+		Name: name,
+		F: func(_ string) error {
+			client, err := ConfigureSweeperClient(name)
+			if err != nil {
+				return fmt.Errorf("error configuring Armis client: %w", err)
+			}
+			if client == nil {
+				return nil
+			}
+
+			ctx := context.Background()
+			sites, err := client.GetSites(ctx)
+			if err != nil {
+				return fmt.Errorf("error listing Armis sites: %w", err)
+			}
+
+			prefix := "tfacc"
+			for _, site := range sites {
+				if strings.HasPrefix(site.Name, prefix) {
+					log.Printf("[INFO] Deleting Armis site: %s", site.Name)
+					_, err := client.DeleteSite(ctx, site.ID)
+					if err != nil {
+						log.Printf("[ERROR] Failed to delete Armis site %s: %s", site.Name, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
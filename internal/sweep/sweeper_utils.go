@@ -6,22 +6,51 @@
 package sweep
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"strconv"
+	"sync"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/credentials"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/transport"
 )
 
+// errInvalidConcurrencyLimit is returned when ARMIS_MAX_CONCURRENT_REQUESTS
+// is not a positive integer.
+var errInvalidConcurrencyLimit = errors.New("ARMIS_MAX_CONCURRENT_REQUESTS must be a positive integer")
+
+// sweeperHTTPClient is shared by every sweeper so that the request limit set
+// with ARMIS_MAX_CONCURRENT_REQUESTS applies to all of them together.
+var sweeperHTTPClient = sync.OnceValues(func() (*http.Client, error) {
+	config := transport.DefaultConfig()
+	if raw := os.Getenv("ARMIS_MAX_CONCURRENT_REQUESTS"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("%w, got %q", errInvalidConcurrencyLimit, raw)
+		}
+		config.MaxConcurrentRequests = limit
+	}
+
+	return transport.NewHTTPClient(config)
+})
+
 // ConfigureSweeperClient initializes an Armis client using environment variables
 // It returns the client and an error if initialization fails.
 func ConfigureSweeperClient(name string) (*armis.Client, error) {
 	// Get configuration from environment variables
-	apiKey := os.Getenv("ARMIS_API_KEY")
-	if apiKey == "" {
-		log.Printf("[INFO] Skipping %s sweeper - ARMIS_API_KEY not set", name)
+	credential, err := credentials.NewResolver("").Resolve(context.Background(), credentials.Config{})
+	if errors.Is(err, credentials.ErrNoCredentials) {
+		log.Printf("[INFO] Skipping %s sweeper - ARMIS_API_KEY and ARMIS_API_KEY_FILE not set", name)
 		return nil, armis.ErrGetKey
 	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading Armis API key: %w", err)
+	}
 
 	apiURL := os.Getenv("ARMIS_API_URL")
 	if apiURL == "" {
@@ -29,10 +58,16 @@ func ConfigureSweeperClient(name string) (*armis.Client, error) {
 		return nil, armis.ErrGetURL
 	}
 
-	// Initialize the client
+	// Initialize the client with the provider's default transport settings
+	httpClient, err := sweeperHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP client: %w", err)
+	}
+
 	client, err := armis.NewClient(
-		apiKey,
+		credential.APIKey,
 		apiURL,
+		armis.WithHTTPClient(httpClient),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Armis client: %w", err)
@@ -0,0 +1,86 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// limitTransport caps the number of requests in flight at the same time.
+// Requests over the limit wait for a slot, or until their context is done.
+// A request holds its slot until its response body is closed, so that
+// responses still streaming count against the limit.
+type limitTransport struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+// NewLimitTransport wraps next so that at most maxConcurrent requests are
+// sent at once. A limit below 1 disables the limiter.
+func NewLimitTransport(next http.RoundTripper, maxConcurrent int) http.RoundTripper {
+	if maxConcurrent < 1 {
+		return next
+	}
+
+	return &limitTransport{
+		next:  next,
+		slots: make(chan struct{}, maxConcurrent),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	select {
+	case t.slots <- struct{}{}:
+	default:
+		start := time.Now()
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		tflog.Debug(ctx, "Waited for a free Armis API request slot", map[string]any{
+			"method":                  req.Method,
+			"path":                    req.URL.Path,
+			"wait":                    time.Since(start).String(),
+			"max_concurrent_requests": cap(t.slots),
+		})
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil || resp.Body == http.NoBody {
+		t.release()
+		return resp, err
+	}
+
+	resp.Body = &slotBody{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+func (t *limitTransport) release() {
+	<-t.slots
+}
+
+// slotBody frees the slot of a request once its response body is closed.
+type slotBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close implements io.Closer.
+func (b *slotBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
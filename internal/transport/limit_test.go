@@ -0,0 +1,142 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitTransport_CapsConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := peak.Load()
+			if n <= current || peak.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	config := noRetry()
+	config.MaxConcurrentRequests = limit
+	client, err := NewHTTPClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error building client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("expected at most %d requests in flight, got %d", limit, got)
+	}
+}
+
+func TestLimitTransport_WaitHonoursContext(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	rt := NewLimitTransport(http.DefaultTransport, 1)
+
+	// Occupy the only slot.
+	go func() {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		if resp, err := rt.RoundTrip(req); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err := rt.RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the waiting request to give up with its context, got %v", err)
+	}
+}
+
+func TestLimitTransport_HoldsSlotUntilBodyClosed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	rt := NewLimitTransport(http.DefaultTransport, 1)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The first body is still open, so its request keeps the only slot.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the second request to wait for the open body, got %v", err)
+	}
+
+	resp.Body.Close()
+	// Closing twice must not free a second slot.
+	resp.Body.Close()
+
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	resp, err = rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected the slot to be free once the body was closed, got %v", err)
+	}
+	resp.Body.Close()
+
+	if n := len(rt.(*limitTransport).slots); n != 0 {
+		t.Errorf("expected every slot to be free, got %d in use", n)
+	}
+}
+
+func TestNewLimitTransport_Disabled(t *testing.T) {
+	t.Parallel()
+
+	if rt := NewLimitTransport(http.DefaultTransport, 0); rt != http.DefaultTransport {
+		t.Errorf("expected a limit of 0 to leave the transport unwrapped, got %T", rt)
+	}
+}
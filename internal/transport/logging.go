@@ -0,0 +1,210 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// LogSubsystem is the tflog subsystem every Armis API request and
+	// response is logged to. Its level can be set on its own with the
+	// TF_LOG_PROVIDER_ARMIS_HTTP environment variable.
+	LogSubsystem = "armis_http"
+
+	// maxLoggedBodyBytes limits how much of a request or response body is
+	// written to the log.
+	maxLoggedBodyBytes = 64 << 10
+
+	redacted = "***"
+)
+
+var (
+	// sensitiveKeys are the body fields and query parameters whose values
+	// never reach the log: API keys and the tokens exchanged for them,
+	// passwords and collector license keys.
+	sensitiveKeys = `api_?key|secret_?key|access_?token|refresh_?token|token|password|license_?key`
+
+	sensitiveJSONValue  = regexp.MustCompile(`(?i)("(?:` + sensitiveKeys + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	sensitiveFormValue  = regexp.MustCompile(`(?i)((?:^|[&?\s])(?:` + sensitiveKeys + `)=)[^&\s]*`)
+	sensitiveHeaderKeys = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie", "X-Api-Key"}
+)
+
+// loggingTransport writes a transcript of every request and response to the
+// armis_http subsystem at TRACE level.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+// NewLoggingTransport wraps next so that requests and responses are logged.
+func NewLoggingTransport(next http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", LogSubsystem))
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "path", req.URL.Path)
+
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Sending Armis API request", map[string]any{
+		"query":   Redact(req.URL.RawQuery),
+		"headers": redactHeaders(req.Header),
+		"body":    newLazyField(func() string { return requestBody(req) }),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemTrace(ctx, LogSubsystem, "Armis API request failed", map[string]any{
+			"latency": latency.String(),
+			"error":   Redact(err.Error()),
+		})
+		return resp, err
+	}
+
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Received Armis API response", map[string]any{
+		"status_code": resp.StatusCode,
+		"latency":     latency.String(),
+		"headers":     redactHeaders(resp.Header),
+		"body":        newLazyField(func() string { return responseBody(resp) }),
+	})
+
+	return resp, nil
+}
+
+// Redact masks the values of sensitive JSON fields and form or query
+// parameters in s.
+func Redact(s string) string {
+	if s == "" {
+		return s
+	}
+
+	s = sensitiveJSONValue.ReplaceAllString(s, `${1}"`+redacted+`"`)
+
+	return sensitiveFormValue.ReplaceAllString(s, "${1}"+redacted)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	values := make(map[string]string, len(header))
+	for key := range header {
+		values[key] = header.Get(key)
+	}
+	for _, key := range sensitiveHeaderKeys {
+		if _, ok := values[key]; ok {
+			values[key] = redacted
+		}
+	}
+
+	return values
+}
+
+// requestBody returns a redacted copy of the request body without consuming
+// it. Bodies that cannot be replayed are not logged.
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	return readLogged(body)
+}
+
+// responseBody returns a redacted copy of the start of the response body and
+// puts it back in front of the unread remainder.
+func responseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+
+	prefix, err := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodyBytes+1))
+	rest := io.Reader(resp.Body)
+	if err != nil {
+		rest = errReader{err}
+	}
+	resp.Body = &replayBody{
+		Reader: io.MultiReader(bytes.NewReader(prefix), rest),
+		Closer: resp.Body,
+	}
+
+	return logged(prefix)
+}
+
+func readLogged(r io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(r, maxLoggedBodyBytes+1))
+
+	return logged(data)
+}
+
+func logged(data []byte) string {
+	truncated := len(data) > maxLoggedBodyBytes
+	if truncated {
+		data = data[:maxLoggedBodyBytes]
+	}
+
+	body := Redact(strings.ToValidUTF8(string(data), "�"))
+	if truncated {
+		body += "... (truncated)"
+	}
+
+	return body
+}
+
+// lazyField is a log field whose value is only computed when the log entry
+// is written. Entries below the level of the armis_http subsystem are
+// dropped before their fields are formatted, so bodies are not read or
+// buffered unless TRACE logging is enabled.
+type lazyField struct {
+	once  sync.Once
+	fn    func() string
+	value string
+}
+
+func newLazyField(fn func() string) *lazyField {
+	return &lazyField{fn: fn}
+}
+
+// String implements fmt.Stringer for plain text logs.
+func (f *lazyField) String() string {
+	f.once.Do(func() { f.value = f.fn() })
+
+	return f.value
+}
+
+// MarshalJSON implements json.Marshaler for JSON logs.
+func (f *lazyField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// replayBody serves the logged prefix of a response body followed by the
+// rest of the original body.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader replays the error that interrupted reading the logged prefix.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "json fields",
+			input:    `{"name": "collector", "password":"s3cr3t", "licenseKey": "ABC-123", "user": "armis"}`,
+			expected: `{"name": "collector", "password":"***", "licenseKey": "***", "user": "armis"}`,
+		},
+		{
+			name:     "escaped quotes",
+			input:    `{"api_key": "a\"b", "next": 1}`,
+			expected: `{"api_key": "***", "next": 1}`,
+		},
+		{
+			name:     "token response",
+			input:    `{"data": {"access_token": "eyJhbGciOi", "expiration_utc": "2025-01-01T00:00:00"}}`,
+			expected: `{"data": {"access_token": "***", "expiration_utc": "2025-01-01T00:00:00"}}`,
+		},
+		{
+			name:     "form body",
+			input:    `secret_key=abc%2Bdef&grant=1`,
+			expected: `secret_key=***&grant=1`,
+		},
+		{
+			name:     "query string",
+			input:    `aql=in%3Adevices&apiKey=abc`,
+			expected: `aql=in%3Adevices&apiKey=***`,
+		},
+		{
+			name:     "nothing sensitive",
+			input:    `{"name": "Password policy"}`,
+			expected: `{"name": "Password policy"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Redact(tt.input); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	t.Parallel()
+
+	const responseBody = `{"data": {"licenseKey": "LIC-42", "name": "edge"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, responseBody)
+	}))
+	t.Cleanup(server.Close)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	config := noRetry()
+	client, err := NewHTTPClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error building client: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/v1/collectors/", strings.NewReader(`{"password": "hunter2"}`))
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+	req.Header.Set("Authorization", "token-value")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if string(body) != responseBody {
+		t.Errorf("expected the response body to be left intact, got %s", body)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decoding log: %v", err)
+	}
+
+	var request, response map[string]any
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "Sending Armis API request":
+			request = entry
+		case "Received Armis API response":
+			response = entry
+		}
+	}
+	if request == nil || response == nil {
+		t.Fatalf("expected a request and a response entry, got %v", entries)
+	}
+
+	if request["@module"] != "provider."+LogSubsystem || request["@level"] != "trace" {
+		t.Errorf("expected a trace entry of the %s subsystem, got %v", LogSubsystem, request)
+	}
+	if request["method"] != http.MethodPost || request["path"] != "/api/v1/collectors/" {
+		t.Errorf("unexpected method or path: %v", request)
+	}
+	if request["body"] != `{"password": "***"}` {
+		t.Errorf("expected the password to be masked, got %v", request["body"])
+	}
+	if headers, _ := request["headers"].(map[string]any); headers["Authorization"] != "***" {
+		t.Errorf("expected the Authorization header to be masked, got %v", request["headers"])
+	}
+	if response["status_code"] != float64(http.StatusCreated) || response["latency"] == nil {
+		t.Errorf("expected status and latency, got %v", response)
+	}
+	if response["body"] != `{"data": {"licenseKey": "***", "name": "edge"}}` {
+		t.Errorf("expected the license key to be masked, got %v", response["body"])
+	}
+
+	if strings.Contains(output.String(), "hunter2") || strings.Contains(output.String(), "LIC-42") || strings.Contains(output.String(), "token-value") {
+		t.Errorf("log leaks a secret: %s", output.String())
+	}
+}
+
+// countingBody records how many times it was read.
+type countingBody struct {
+	io.Reader
+	reads int
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	b.reads++
+	return b.Reader.Read(p)
+}
+
+func (b *countingBody) Close() error { return nil }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestLoggingTransport_DoesNotReadBodiesBelowTrace(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_"+strings.ToUpper(LogSubsystem), "DEBUG")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	body := &countingBody{Reader: strings.NewReader(`{"data": {}}`)}
+	transport := NewLoggingTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body}, nil
+	}))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/api/v1/sites/", nil)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body.reads != 0 {
+		t.Errorf("expected the response body not to be read, got %d reads", body.reads)
+	}
+	if resp.Body != body {
+		t.Errorf("expected the response body to be passed through unchanged")
+	}
+	if output.Len() != 0 {
+		t.Errorf("expected no log output, got %s", output.String())
+	}
+}
+
+func TestResponseBody_LargeBodiesAreTruncatedButIntact(t *testing.T) {
+	t.Parallel()
+
+	original := strings.Repeat("a", maxLoggedBodyBytes+10)
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(original))}
+
+	logged := responseBody(resp)
+	if !strings.HasSuffix(logged, "... (truncated)") {
+		t.Errorf("expected the logged body to be truncated")
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if string(body) != original {
+		t.Errorf("expected %d bytes, got %d", len(original), len(body))
+	}
+}
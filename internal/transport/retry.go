@@ -0,0 +1,342 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultMaxAttempts is the total number of attempts, including the
+	// first one, made for a request before giving up.
+	DefaultMaxAttempts = 4
+	// DefaultMinBackoff is the wait before the first retry.
+	DefaultMinBackoff = 1 * time.Second
+	// DefaultMaxBackoff caps the exponential backoff between retries.
+	DefaultMaxBackoff = 30 * time.Second
+
+	// maxErrorBodyBytes limits how much of a failed response is kept for
+	// diagnostics once retries are exhausted.
+	maxErrorBodyBytes = 1 << 20
+)
+
+// RetryConfig controls how failed Armis API requests are retried.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 disables retries.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry. Subsequent waits double
+	// until MaxBackoff is reached.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed backoff.
+	MaxBackoff time.Duration
+	// Jitter randomizes each wait between half and all of the computed
+	// backoff so that parallel operations do not retry in lockstep.
+	Jitter bool
+	// RespectRetryAfter uses the Retry-After response header, when present,
+	// instead of the computed backoff. A Retry-After longer than MaxBackoff
+	// ends the retries instead.
+	RespectRetryAfter bool
+}
+
+// DefaultRetryConfig returns the retry policy used when the provider block
+// does not configure one.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:       DefaultMaxAttempts,
+		MinBackoff:        DefaultMinBackoff,
+		MaxBackoff:        DefaultMaxBackoff,
+		Jitter:            true,
+		RespectRetryAfter: true,
+	}
+}
+
+// RetryError is returned once a request has failed on every allowed attempt.
+// It carries the last status code and response body, or the last transport
+// error when no response was received.
+type RetryError struct {
+	Attempts   int
+	StatusCode int
+	Body       []byte
+	Err        error
+}
+
+func (e *RetryError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("request failed after %d attempts: %v", e.Attempts, e.Err)
+	}
+
+	return fmt.Sprintf("request failed after %d attempts: %d %s", e.Attempts, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// retryTransport retries requests that failed with a throttling or transient
+// server error.
+type retryTransport struct {
+	next   http.RoundTripper
+	config RetryConfig
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryTransport wraps next with the given retry policy.
+func NewRetryTransport(next http.RoundTripper, config RetryConfig) http.RoundTripper {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+
+	return &retryTransport{
+		next:   next,
+		config: config,
+		sleep:  sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := makeRewindable(req); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := requestForAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		if attempt >= t.config.MaxAttempts {
+			if attempt == 1 {
+				// Retries are disabled, so report the outcome as is.
+				return resp, err
+			}
+			return nil, exhausted(attempt, resp, err)
+		}
+
+		wait, ok := t.backoff(attempt, resp)
+		if !ok || exceedsDeadline(ctx, wait) {
+			// Waiting as long as the server asks would outlast the
+			// operation, so report the last outcome now.
+			return nil, exhausted(attempt, resp, err)
+		}
+
+		fields := map[string]any{
+			"method":       req.Method,
+			"path":         req.URL.Path,
+			"attempt":      attempt,
+			"max_attempts": t.config.MaxAttempts,
+			"wait":         wait.String(),
+		}
+		if resp != nil {
+			fields["status_code"] = resp.StatusCode
+			drainAndClose(resp)
+		} else {
+			fields["error"] = err.Error()
+		}
+		tflog.Warn(ctx, "Armis API request failed, retrying", fields)
+
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt is worth retrying.
+// Requests that may not be idempotent are only retried when the server
+// signalled that it did not process them.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// A certificate the client rejects will be rejected again.
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return false
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		// A request that failed after it was sent may have been processed.
+		return isIdempotent(req.Method) || notSent(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the attempt following the given one. It
+// returns false when the server asks, through Retry-After, for a wait longer
+// than MaxBackoff.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if t.config.RespectRetryAfter && resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait, t.config.MaxBackoff <= 0 || wait <= t.config.MaxBackoff
+		}
+	}
+
+	wait := t.config.MinBackoff
+	for i := 1; i < attempt && wait < t.config.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if t.config.MaxBackoff > 0 && wait > t.config.MaxBackoff {
+		wait = t.config.MaxBackoff
+	}
+
+	if t.config.Jitter && wait > 1 {
+		half := wait / 2
+		wait = half + time.Duration(rand.Int64N(int64(wait-half)+1)) //nolint:gosec // Jitter does not need a secure source.
+	}
+
+	return wait, true
+}
+
+// exceedsDeadline reports whether waiting for d would pass the deadline of
+// ctx.
+func exceedsDeadline(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < d
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// notSent reports whether err shows that the request never reached the
+// server, because no connection could be established.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// makeRewindable ensures the request body can be replayed on a retry.
+func makeRewindable(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("reading request body: %w", err)
+	}
+	_ = req.Body.Close()
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return nil
+}
+
+func requestForAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 {
+		return req, nil
+	}
+
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewinding request body: %w", err)
+		}
+		clone.Body = body
+	}
+
+	return clone, nil
+}
+
+func exhausted(attempts int, resp *http.Response, err error) *RetryError {
+	retryErr := &RetryError{Attempts: attempts, Err: err}
+	if resp != nil {
+		retryErr.StatusCode = resp.StatusCode
+		retryErr.Body, _ = io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		_ = resp.Body.Close()
+	}
+
+	return retryErr
+}
+
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodyBytes))
+	_ = resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestRetryTransport returns a retry transport that records waits instead
+// of sleeping.
+func newTestRetryTransport(config RetryConfig, waits *[]time.Duration) *retryTransport {
+	rt := NewRetryTransport(http.DefaultTransport, config).(*retryTransport)
+	rt.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+
+	return rt
+}
+
+// statusSequenceServer answers with the given status codes in order and
+// repeats the last one once the sequence is exhausted.
+func statusSequenceServer(t *testing.T, statuses []int, header http.Header) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"message":"status ` + http.StatusText(status) + `"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestRetryTransport_RetriesUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	server, calls := statusSequenceServer(t, []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}, nil)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryConfig{
+		MaxAttempts: 4,
+		MinBackoff:  time.Second,
+		MaxBackoff:  10 * time.Second,
+	}, &waits)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+	if len(waits) != 2 || waits[0] != time.Second || waits[1] != 2*time.Second {
+		t.Errorf("expected waits [1s 2s], got %v", waits)
+	}
+}
+
+func TestRetryTransport_ExhaustedReturnsRetryError(t *testing.T) {
+	t.Parallel()
+
+	server, calls := statusSequenceServer(t, []int{http.StatusTooManyRequests}, nil)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryConfig{
+		MaxAttempts: 3,
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Second,
+	}, &waits)}
+
+	resp, err := client.Get(server.URL)
+	if resp != nil {
+		resp.Body.Close()
+		t.Fatal("expected no response once retries are exhausted")
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("expected *RetryError, got %T: %v", err, err)
+	}
+	if retryErr.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", retryErr.Attempts)
+	}
+	if retryErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", retryErr.StatusCode)
+	}
+	if !strings.Contains(string(retryErr.Body), "Too Many Requests") {
+		t.Errorf("expected last response body to be kept, got %q", retryErr.Body)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestRetryTransport_HonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		respect  bool
+		expected time.Duration
+	}{
+		{"retry-after respected", true, 7 * time.Second},
+		{"retry-after ignored", false, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{"Retry-After": []string{"7"}}
+			server, _ := statusSequenceServer(t, []int{http.StatusTooManyRequests, http.StatusOK}, header)
+
+			var waits []time.Duration
+			client := &http.Client{Transport: newTestRetryTransport(RetryConfig{
+				MaxAttempts:       2,
+				MinBackoff:        500 * time.Millisecond,
+				MaxBackoff:        10 * time.Second,
+				RespectRetryAfter: tt.respect,
+			}, &waits)}
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if len(waits) != 1 || waits[0] != tt.expected {
+				t.Errorf("expected a single wait of %s, got %v", tt.expected, waits)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_GivesUpOnLongRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		timeout    time.Duration
+	}{
+		{"longer than max backoff", "3600", 0},
+		{"far-future date", time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat), 0},
+		{"longer than the deadline", "20", 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{"Retry-After": []string{tt.retryAfter}}
+			server, calls := statusSequenceServer(t, []int{http.StatusTooManyRequests, http.StatusOK}, header)
+
+			var waits []time.Duration
+			client := &http.Client{Transport: newTestRetryTransport(RetryConfig{
+				MaxAttempts:       4,
+				MinBackoff:        time.Second,
+				MaxBackoff:        30 * time.Second,
+				RespectRetryAfter: true,
+			}, &waits)}
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+			resp, err := client.Do(req)
+			if resp != nil {
+				resp.Body.Close()
+				t.Fatal("expected no response when the server asks for a longer wait")
+			}
+
+			var retryErr *RetryError
+			if !errors.As(err, &retryErr) {
+				t.Fatalf("expected *RetryError, got %T: %v", err, err)
+			}
+			if retryErr.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("expected status 429, got %d", retryErr.StatusCode)
+			}
+			if len(waits) != 0 {
+				t.Errorf("expected no waits, got %v", waits)
+			}
+			if got := atomic.LoadInt32(calls); got != 1 {
+				t.Errorf("expected 1 call, got %d", got)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_DoesNotRetryNonIdempotentOnBadGateway(t *testing.T) {
+	t.Parallel()
+
+	server, calls := statusSequenceServer(t, []int{http.StatusBadGateway}, nil)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(DefaultRetryConfig(), &waits)}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected a single call, got %d", got)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryNonIdempotentAfterConnectionReset(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		// Reset the connection once the request has been received.
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijacking connection: %v", err)
+			return
+		}
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			_ = tcpConn.SetLinger(0)
+		}
+		_ = conn.Close()
+	}))
+	t.Cleanup(server.Close)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(DefaultRetryConfig(), &waits)}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected a connection error")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected a single call, got %d", got)
+	}
+	if len(waits) != 0 {
+		t.Errorf("expected no retries, got waits %v", waits)
+	}
+}
+
+func TestRetryTransport_RetriesNonIdempotentWhenConnectionRefused(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryConfig{MaxAttempts: 3}, &waits)}
+
+	resp, err := client.Post(url, "application/json", strings.NewReader(`{}`))
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected a connection error")
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+		t.Errorf("expected a RetryError after 3 attempts, got %v", err)
+	}
+	if len(waits) != 2 {
+		t.Errorf("expected 2 retries, got waits %v", waits)
+	}
+}
+
+func TestRetryTransport_ReplaysBody(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	var waits []time.Duration
+	rt := newTestRetryTransport(DefaultRetryConfig(), &waits)
+
+	// io.NopCloser hides the concrete reader so http.NewRequest cannot set GetBody.
+	req, _ := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader(`{"name":"policy"}`)))
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] != `{"name":"policy"}` {
+		t.Errorf("expected the request body to be replayed, got %q", bodies)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	server, calls := statusSequenceServer(t, []int{http.StatusBadRequest}, nil)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(DefaultRetryConfig(), &waits)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected a single call, got %d", got)
+	}
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	t.Parallel()
+
+	rt := NewRetryTransport(http.DefaultTransport, RetryConfig{
+		MaxAttempts: 10,
+		MinBackoff:  time.Second,
+		MaxBackoff:  5 * time.Second,
+		Jitter:      true,
+	}).(*retryTransport)
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		ceiling := time.Second << (attempt - 1)
+		if ceiling > 5*time.Second {
+			ceiling = 5 * time.Second
+		}
+
+		wait, ok := rt.backoff(attempt, nil)
+		if !ok {
+			t.Errorf("attempt %d: expected a retry", attempt)
+		}
+		if wait < ceiling/2 || wait > ceiling {
+			t.Errorf("attempt %d: expected wait in [%s, %s], got %s", attempt, ceiling/2, ceiling, wait)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "12", 12 * time.Second, true},
+		{"negative seconds", "-1", 0, false},
+		{"http date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{"http date in the past", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			wait, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.ok || wait != tt.expected {
+				t.Errorf("parseRetryAfter(%q) = %s, %t; expected %s, %t", tt.value, wait, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_DoesNotRetryCertificateErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(DefaultRetryConfig(), &waits)}
+
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected a certificate verification error")
+	}
+
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		t.Errorf("expected the certificate error to be returned without retrying, got %v", err)
+	}
+	if len(waits) != 0 {
+		t.Errorf("expected no retries, got waits %v", waits)
+	}
+}
+
+func TestRetryTransport_SingleAttemptReturnsResponse(t *testing.T) {
+	t.Parallel()
+
+	server, calls := statusSequenceServer(t, []int{http.StatusServiceUnavailable}, nil)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(RetryConfig{MaxAttempts: 1}, &waits)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected a single call, got %d", got)
+	}
+}
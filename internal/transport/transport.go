@@ -0,0 +1,148 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package transport builds the HTTP client handed to the Armis SDK. It layers
+// provider-wide behaviour such as TLS settings, proxies and retries on top of
+// the standard library transport so that every API call made by resources,
+// data sources and sweepers is treated the same way.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+var (
+	// ErrNoCertificates is returned when a CA bundle does not contain any
+	// PEM encoded certificate.
+	ErrNoCertificates = errors.New("no PEM encoded certificates found")
+	// ErrIncompleteClientCertificate is returned when only one of the client
+	// certificate and key is set.
+	ErrIncompleteClientCertificate = errors.New("client certificate and client key must be set together")
+	// ErrUnsupportedProxyScheme is returned for proxy URLs that are not
+	// http, https or socks5.
+	ErrUnsupportedProxyScheme = errors.New("unsupported proxy scheme")
+)
+
+// Config describes the HTTP behaviour of the Armis API client.
+type Config struct {
+	Retry RetryConfig
+	TLS   TLSConfig
+	// ProxyURL is the proxy every request is sent through. When empty the
+	// standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	// apply.
+	ProxyURL string
+	// MaxConcurrentRequests caps the number of requests sent at the same
+	// time by every user of the client. Zero means no limit.
+	MaxConcurrentRequests int
+}
+
+// TLSConfig controls how the Armis API server is authenticated and how the
+// client authenticates itself.
+type TLSConfig struct {
+	// CABundleFile is a PEM file of certificate authorities trusted in
+	// addition to the system pool, for example the root of a TLS inspecting
+	// proxy.
+	CABundleFile string
+	// ClientCertificateFile and ClientKeyFile are a PEM encoded certificate
+	// and private key presented to servers that require mutual TLS.
+	ClientCertificateFile string
+	ClientKeyFile         string
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// DefaultConfig returns the configuration used when the provider block does
+// not override any transport settings.
+func DefaultConfig() Config {
+	return Config{
+		Retry: DefaultRetryConfig(),
+	}
+}
+
+// NewHTTPClient builds an *http.Client that applies the given configuration
+// to every request.
+func NewHTTPClient(config Config) (*http.Client, error) {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig, err := newTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	base.TLSClientConfig = tlsConfig
+
+	if config.ProxyURL != "" {
+		proxyURL, err := ParseProxyURL(config.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		base.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// The limiter sits below the retry transport so that a request waiting
+	// to be retried does not hold a slot during its backoff. Every attempt
+	// is logged separately.
+	return &http.Client{
+		Transport: NewRetryTransport(NewLimitTransport(NewLoggingTransport(base), config.MaxConcurrentRequests), config.Retry),
+	}, nil
+}
+
+// ParseProxyURL validates a proxy URL.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing proxy URL: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("%w %q: expected http, https or socks5", ErrUnsupportedProxyScheme, proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("parsing proxy URL: missing host in %q", raw)
+	}
+
+	return proxyURL, nil
+}
+
+func newTLSConfig(config TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec // Opt-in for lab tenants with self-signed certificates.
+	}
+
+	if config.CABundleFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		pem, err := os.ReadFile(config.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("reading CA bundle %s: %w", config.CABundleFile, ErrNoCertificates)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (config.ClientCertificateFile == "") != (config.ClientKeyFile == "") {
+		return nil, ErrIncompleteClientCertificate
+	}
+	if config.ClientCertificateFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCertificateFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
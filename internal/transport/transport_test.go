@@ -0,0 +1,275 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// noRetry keeps failing requests from being retried so that TLS errors
+// surface immediately.
+func noRetry() Config {
+	config := DefaultConfig()
+	config.Retry.MaxAttempts = 1
+	return config
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+
+	return path
+}
+
+// writeServerCA writes the certificate of a httptest TLS server to a PEM file.
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+
+	return writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+}
+
+// newClientCertificate creates a self-signed client certificate and returns
+// it with the paths of its PEM encoded certificate and key.
+func newClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-armis-centrix"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshalling key: %v", err)
+	}
+
+	return cert, writePEM(t, "client.pem", "CERTIFICATE", der), writePEM(t, "client-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestNewHTTPClient_ServerVerification(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(okHandler())
+	t.Cleanup(server.Close)
+
+	caFile := writeServerCA(t, server)
+
+	tests := []struct {
+		name      string
+		tls       TLSConfig
+		expectErr bool
+	}{
+		{"untrusted server is rejected", TLSConfig{}, true},
+		{"CA bundle trusts the server", TLSConfig{CABundleFile: caFile}, false},
+		{"verification can be skipped", TLSConfig{InsecureSkipVerify: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := noRetry()
+			config.TLS = tt.tls
+
+			client, err := NewHTTPClient(config)
+			if err != nil {
+				t.Fatalf("unexpected error building client: %v", err)
+			}
+
+			resp, err := client.Get(server.URL)
+			if tt.expectErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatal("expected a certificate verification error")
+				}
+				var unknownAuthority x509.UnknownAuthorityError
+				if !errors.As(err, &unknownAuthority) {
+					t.Errorf("expected x509.UnknownAuthorityError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestNewHTTPClient_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	clientCert, certFile, keyFile := newClientCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := httptest.NewUnstartedServer(okHandler())
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile := writeServerCA(t, server)
+
+	tests := []struct {
+		name      string
+		tls       TLSConfig
+		expectErr bool
+	}{
+		{"missing client certificate is rejected", TLSConfig{CABundleFile: caFile}, true},
+		{"client certificate is presented", TLSConfig{CABundleFile: caFile, ClientCertificateFile: certFile, ClientKeyFile: keyFile}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := noRetry()
+			config.TLS = tt.tls
+
+			client, err := NewHTTPClient(config)
+			if err != nil {
+				t.Fatalf("unexpected error building client: %v", err)
+			}
+
+			resp, err := client.Get(server.URL)
+			if tt.expectErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatal("expected the handshake to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(proxy.Close)
+
+	config := noRetry()
+	config.ProxyURL = proxy.URL
+
+	client, err := NewHTTPClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error building client: %v", err)
+	}
+
+	resp, err := client.Get("http://armis.invalid/api/v1/policies/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://armis.invalid/api/v1/policies/" {
+		t.Errorf("expected the request to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestNewHTTPClient_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	emptyBundle := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(emptyBundle, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("writing bundle: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:        "CA bundle without certificates",
+			config:      Config{TLS: TLSConfig{CABundleFile: emptyBundle}},
+			expectedErr: ErrNoCertificates,
+		},
+		{
+			name:        "missing CA bundle",
+			config:      Config{TLS: TLSConfig{CABundleFile: filepath.Join(t.TempDir(), "missing.pem")}},
+			expectedErr: os.ErrNotExist,
+		},
+		{
+			name:        "client certificate without key",
+			config:      Config{TLS: TLSConfig{ClientCertificateFile: "client.pem"}},
+			expectedErr: ErrIncompleteClientCertificate,
+		},
+		{
+			name:        "unsupported proxy scheme",
+			config:      Config{ProxyURL: "ftp://proxy.example.com"},
+			expectedErr: ErrUnsupportedProxyScheme,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewHTTPClient(tt.config)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
@@ -3,19 +3,38 @@
 
 package utils
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/asqtypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MaxRuleGroupDepth is the number of levels rule groups can be nested.
+// Terraform schemas cannot be recursive, so the rules schema is generated to
+// this depth, and reading rules nested deeper fails.
+const MaxRuleGroupDepth = 5
+
+// Rule operators. They are also the keys of the rules returned by the API,
+// such as {"or": ["protocol:BMS", "protocol:SMB"]}, and select which of them
+// the groups of a rule group join.
+const (
+	RuleOperatorAnd = "and"
+	RuleOperatorOr  = "or"
+)
 
 // PolicyResourceModel maps the resource schema data.
 type PolicyResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Description       types.String `tfsdk:"description"`
-	IsEnabled         types.Bool   `tfsdk:"enabled"`
-	Labels            types.List   `tfsdk:"labels"`
-	MitreAttackLabels types.List   `tfsdk:"mitre_attack_labels"`
-	RuleType          types.String `tfsdk:"rule_type"`
-	Actions           types.List   `tfsdk:"actions"`
-	Rules             *RulesModel  `tfsdk:"rules"`
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Description       types.String   `tfsdk:"description"`
+	IsEnabled         types.Bool     `tfsdk:"enabled"`
+	Labels            types.List     `tfsdk:"labels"`
+	MitreAttackLabels types.List     `tfsdk:"mitre_attack_labels"`
+	RuleType          types.String   `tfsdk:"rule_type"`
+	Actions           types.List     `tfsdk:"actions"`
+	Rules             *RulesModel    `tfsdk:"rules"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 // ActionModel maps the action schema data.
@@ -42,8 +61,27 @@ type ConsolidationModel struct {
 
 // RulesModel maps the rules schema data.
 type RulesModel struct {
-	And types.List `tfsdk:"and"`
-	Or  types.List `tfsdk:"or"`
+	Operator types.String `tfsdk:"operator"`
+	And      types.List   `tfsdk:"and"`
+	Or       types.List   `tfsdk:"or"`
+	Groups   types.List   `tfsdk:"groups"`
+}
+
+// RuleGroupObjectType returns the type of a rule group nested depth levels
+// deep, starting at 1 for the groups of rules. A rule group has the
+// attributes of rules, except that groups at MaxRuleGroupDepth have no
+// operator or groups attributes.
+func RuleGroupObjectType(depth int) types.ObjectType {
+	attrTypes := map[string]attr.Type{
+		"and": types.ListType{ElemType: asqtypes.QueryType{}},
+		"or":  types.ListType{ElemType: asqtypes.QueryType{}},
+	}
+	if depth < MaxRuleGroupDepth {
+		attrTypes["operator"] = types.StringType
+		attrTypes["groups"] = types.ListType{ElemType: RuleGroupObjectType(depth + 1)}
+	}
+
+	return types.ObjectType{AttrTypes: attrTypes}
 }
 
 type PoliciesDataSourceModel struct {
@@ -92,6 +130,8 @@ type PolicyDataSourceConsolidationModel struct {
 }
 
 type PolicyDataSourceRulesModel struct {
-	And []types.String `tfsdk:"and"`
-	Or  []types.String `tfsdk:"or"`
+	Operator types.String   `tfsdk:"operator"`
+	And      []types.String `tfsdk:"and"`
+	Or       []types.String `tfsdk:"or"`
+	Groups   types.List     `tfsdk:"groups"`
 }
@@ -4,6 +4,7 @@
 package utils
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -12,6 +13,7 @@ type RoleResourceModel struct {
 	Name        types.String      `tfsdk:"name"`
 	Permissions *PermissionsModel `tfsdk:"permissions"`
 	ID          types.String      `tfsdk:"id"`
+	Timeouts    timeouts.Value    `tfsdk:"timeouts"`
 }
 
 // RoleDataSourceModel defines the structure for the role data source model.
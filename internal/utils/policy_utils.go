@@ -5,9 +5,13 @@ package utils
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/asqtypes"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -15,34 +19,94 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// ConvertModelToRules converts RulesModel to armis.Rules.
+// ConvertModelToRules converts RulesModel to armis.Rules. Groups are nested
+// in the rules selected by the operator, as objects such as
+// {"or": ["protocol:BMS", {"and": [...]}]}.
 func ConvertModelToRules(model RulesModel) (armis.Rules, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	rules := armis.Rules{}
-
-	// Convert AND rules
-	if !model.And.IsNull() && !model.And.IsUnknown() {
-		andElements := model.And.Elements()
-		rules.And = make([]any, 0, len(andElements))
-		for _, elem := range andElements {
-			if strVal, ok := elem.(types.String); ok && !strVal.IsNull() {
-				rules.And = append(rules.And, strVal.ValueString())
+	rules := armis.Rules{
+		And: convertListToRules(model.And),
+		Or:  convertListToRules(model.Or),
+	}
+
+	groups := convertListToRuleGroups(model.Groups, &diags)
+	if len(groups) == 0 {
+		return rules, diags
+	}
+
+	switch model.Operator.ValueString() {
+	case RuleOperatorAnd:
+		rules.And = append(rules.And, groups...)
+	case RuleOperatorOr:
+		rules.Or = append(rules.Or, groups...)
+	default:
+		diags.AddError(
+			"Invalid Policy Rules",
+			fmt.Sprintf("The operator of rules with groups must be '%s' or '%s', got %q.", RuleOperatorAnd, RuleOperatorOr, model.Operator.ValueString()),
+		)
+	}
+
+	return rules, diags
+}
+
+// convertListToRules converts a list of ASQ rules to the API format, skipping
+// null rules. It returns nil for a null or unknown list.
+func convertListToRules(list types.List) []any {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	elements := list.Elements()
+	rules := make([]any, 0, len(elements))
+	for _, elem := range elements {
+		if rule, ok := elem.(basetypes.StringValuable); ok {
+			if strVal, _ := rule.ToStringValue(context.Background()); !strVal.IsNull() {
+				rules = append(rules, strVal.ValueString())
 			}
 		}
 	}
 
-	// Convert OR rules
-	if !model.Or.IsNull() && !model.Or.IsUnknown() {
-		orElements := model.Or.Elements()
-		rules.Or = make([]any, 0, len(orElements))
-		for _, elem := range orElements {
-			if strVal, ok := elem.(types.String); ok && !strVal.IsNull() {
-				rules.Or = append(rules.Or, strVal.ValueString())
-			}
+	return rules
+}
+
+// convertListToRuleGroups converts a list of rule groups to the objects the
+// API nests in its rules.
+func convertListToRuleGroups(list types.List, diags *diag.Diagnostics) []any {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	elements := list.Elements()
+	groups := make([]any, 0, len(elements))
+	for _, elem := range elements {
+		group, ok := elem.(types.Object)
+		if !ok || group.IsNull() || group.IsUnknown() {
+			continue
+		}
+
+		// Groups at MaxRuleGroupDepth have no operator or groups, which
+		// leaves them null here.
+		attrs := group.Attributes()
+		model := RulesModel{}
+		model.Operator, _ = attrs["operator"].(types.String)
+		model.And, _ = attrs["and"].(types.List)
+		model.Or, _ = attrs["or"].(types.List)
+		model.Groups, _ = attrs["groups"].(types.List)
+
+		rules, groupDiags := ConvertModelToRules(model)
+		diags.Append(groupDiags...)
+
+		operands := map[string]any{}
+		if rules.And != nil {
+			operands[RuleOperatorAnd] = rules.And
+		}
+		if rules.Or != nil {
+			operands[RuleOperatorOr] = rules.Or
 		}
+		groups = append(groups, operands)
 	}
 
-	return rules, diags
+	return groups
 }
 
 // ConvertListToStringSlice converts a types.List to []string.
@@ -114,15 +178,173 @@ func ConvertStringSliceToList(slice []string) types.List {
 	return listValue
 }
 
+// ConvertSliceToRuleList converts []any to a types.List of ASQ rules. The
+// list is null when input is nil or holds only rule groups, which are
+// converted by ConvertRulesToModel.
+func ConvertSliceToRuleList(input []any) types.List {
+	if input == nil || (len(input) > 0 && !slices.ContainsFunc(input, isRule)) {
+		return types.ListNull(asqtypes.QueryType{})
+	}
+
+	elements := make([]attr.Value, 0, len(input))
+	for _, item := range input {
+		if str, ok := item.(string); ok {
+			elements = append(elements, asqtypes.NewQueryValue(str))
+		}
+	}
+
+	listValue, _ := types.ListValue(asqtypes.QueryType{}, elements)
+	return listValue
+}
+
+// isRule reports whether an item of the rules returned by the API is a rule
+// rather than a rule group.
+func isRule(item any) bool {
+	_, ok := item.(map[string]any)
+	return !ok
+}
+
+// ConvertRulesToModel converts the rules returned by the API to RulesModel.
+// The operator is taken from the rules holding groups. Rules that the schema
+// cannot represent, such as groups in both the and and or rules or groups
+// nested deeper than MaxRuleGroupDepth, are reported as errors rather than
+// dropped.
+func ConvertRulesToModel(rules armis.Rules) (*RulesModel, diag.Diagnostics) {
+	return convertRulesToModel(rules, 0)
+}
+
+// convertRulesToModel converts rules nested depth levels deep, starting at 0
+// for the rules of a policy. The rules of groups are null rather than empty
+// when the API returns none, as nothing in the state is kept for them.
+func convertRulesToModel(rules armis.Rules, depth int) (*RulesModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	model := &RulesModel{
+		Operator: types.StringNull(),
+		And:      ConvertSliceToRuleList(rules.And),
+		Or:       ConvertSliceToRuleList(rules.Or),
+		Groups:   types.ListNull(RuleGroupObjectType(depth + 1)),
+	}
+	if depth > 0 {
+		model.And = nullIfEmpty(model.And)
+		model.Or = nullIfEmpty(model.Or)
+	}
+
+	andGroups := slices.DeleteFunc(slices.Clone(rules.And), isRule)
+	orGroups := slices.DeleteFunc(slices.Clone(rules.Or), isRule)
+
+	groups, operator := andGroups, RuleOperatorAnd
+	switch {
+	case len(andGroups) > 0 && len(orGroups) > 0:
+		diags.AddError(
+			"Unsupported Policy Rules",
+			"The policy rules hold groups in both their and and or rules, which the rules schema cannot represent. "+
+				"Move the groups of the policy under one operator in Armis.",
+		)
+		return model, diags
+	case len(orGroups) > 0:
+		groups, operator = orGroups, RuleOperatorOr
+	case len(andGroups) == 0:
+		return model, diags
+	}
+
+	if depth >= MaxRuleGroupDepth {
+		diags.AddError(
+			"Unsupported Policy Rules",
+			fmt.Sprintf("The policy rules nest groups more than %d levels deep, which the rules schema cannot represent. "+
+				"Flatten the rules of the policy in Armis.", MaxRuleGroupDepth),
+		)
+		return model, diags
+	}
+
+	model.Operator = types.StringValue(operator)
+	model.Groups = convertSliceToRuleGroupList(groups, depth+1, &diags)
+
+	return model, diags
+}
+
+// convertSliceToRuleGroupList converts the rule groups returned by the API,
+// nested depth levels deep, to a types.List.
+func convertSliceToRuleGroupList(input []any, depth int, diags *diag.Diagnostics) types.List {
+	elemType := RuleGroupObjectType(depth)
+
+	elements := make([]attr.Value, 0, len(input))
+	for _, item := range input {
+		group, _ := item.(map[string]any)
+
+		var rules armis.Rules
+		for key, value := range group {
+			operands, ok := value.([]any)
+			switch {
+			case key == RuleOperatorAnd && ok:
+				rules.And = operands
+			case key == RuleOperatorOr && ok:
+				rules.Or = operands
+			default:
+				diags.AddError(
+					"Unsupported Policy Rules",
+					fmt.Sprintf("The policy rules hold a group with the unsupported operator %q. Rule groups can only use '%s' and '%s'.",
+						key, RuleOperatorAnd, RuleOperatorOr),
+				)
+			}
+		}
+
+		model, groupDiags := convertRulesToModel(rules, depth)
+		diags.Append(groupDiags...)
+
+		attrs := map[string]attr.Value{
+			"and": model.And,
+			"or":  model.Or,
+		}
+		if depth < MaxRuleGroupDepth {
+			attrs["operator"] = model.Operator
+			attrs["groups"] = model.Groups
+		}
+		elements = append(elements, types.ObjectValueMust(elemType.AttrTypes, attrs))
+	}
+
+	return types.ListValueMust(elemType, elements)
+}
+
+// nullIfEmpty returns a null list in place of an empty one.
+func nullIfEmpty(list types.List) types.List {
+	if !list.IsNull() && len(list.Elements()) == 0 {
+		return types.ListNull(list.ElementType(context.Background()))
+	}
+
+	return list
+}
+
+// PreserveRules keeps the and and or rules of prior in result when the API
+// omitted them from rules, or returned them without any rule while prior
+// holds none either. An API echoing "and": [] then leaves null rules null.
+func PreserveRules(result, prior *RulesModel, rules armis.Rules) {
+	if result == nil || prior == nil {
+		return
+	}
+
+	result.And = preserveRuleList(result.And, prior.And, rules.And)
+	result.Or = preserveRuleList(result.Or, prior.Or, rules.Or)
+}
+
+// preserveRuleList returns prior in place of list, converted from input,
+// when input is nil or neither list holds a rule.
+func preserveRuleList(list, prior types.List, input []any) types.List {
+	if prior.IsUnknown() {
+		return list
+	}
+	if (input == nil && !prior.IsNull()) || (len(list.Elements()) == 0 && len(prior.Elements()) == 0) {
+		return prior
+	}
+
+	return list
+}
+
 // BuildPolicyDataSourceModelFromGet converts armis.GetPolicySettings to PolicyDataSourcePolicyModel.
-func BuildPolicyDataSourceModelFromGet(policy armis.GetPolicySettings, id string) PolicyDataSourcePolicyModel {
+func BuildPolicyDataSourceModelFromGet(policy armis.GetPolicySettings, id string) (PolicyDataSourcePolicyModel, diag.Diagnostics) {
 	labels := convertStringsToTypeStrings(policy.Labels)
 	mitreLabels := convertMitreLabelsToDataSource(policy.MitreAttackLabels)
 	actions := convertActionsToDataSource(policy.Actions)
-	rules := PolicyDataSourceRulesModel{
-		And: convertInterfacesToTypeStrings(policy.Rules.And),
-		Or:  convertInterfacesToTypeStrings(policy.Rules.Or),
-	}
+	rules, diags := convertRulesToDataSource(policy.Rules)
 
 	policyID := types.StringNull()
 	if id != "" {
@@ -139,11 +361,11 @@ func BuildPolicyDataSourceModelFromGet(policy armis.GetPolicySettings, id string
 		MitreAttackLabels: mitreLabels,
 		Actions:           actions,
 		Rules:             rules,
-	}
+	}, diags
 }
 
 // BuildPolicyDataSourceModelFromSingle converts armis.SinglePolicy to PolicyDataSourcePolicyModel.
-func BuildPolicyDataSourceModelFromSingle(policy armis.SinglePolicy) PolicyDataSourcePolicyModel {
+func BuildPolicyDataSourceModelFromSingle(policy armis.SinglePolicy) (PolicyDataSourcePolicyModel, diag.Diagnostics) {
 	labels := convertStringsToTypeStrings(policy.Labels)
 	mitreLabels := convertMitreLabelsToDataSource(policy.MitreAttackLabels)
 	actions := convertActionsToDataSource(policy.Actions)
@@ -152,10 +374,7 @@ func BuildPolicyDataSourceModelFromSingle(policy armis.SinglePolicy) PolicyDataS
 		actions = append(actions, convertActionToDataSource(policy.Action))
 	}
 
-	rules := PolicyDataSourceRulesModel{
-		And: convertInterfacesToTypeStrings(policy.Rules.And),
-		Or:  convertInterfacesToTypeStrings(policy.Rules.Or),
-	}
+	rules, diags := convertRulesToDataSource(policy.Rules)
 
 	return PolicyDataSourcePolicyModel{
 		ID:                types.StringValue(policy.ID),
@@ -167,7 +386,20 @@ func BuildPolicyDataSourceModelFromSingle(policy armis.SinglePolicy) PolicyDataS
 		MitreAttackLabels: mitreLabels,
 		Actions:           actions,
 		Rules:             rules,
-	}
+	}, diags
+}
+
+// convertRulesToDataSource converts the rules returned by the API to
+// PolicyDataSourceRulesModel.
+func convertRulesToDataSource(rules armis.Rules) (PolicyDataSourceRulesModel, diag.Diagnostics) {
+	model, diags := ConvertRulesToModel(rules)
+
+	return PolicyDataSourceRulesModel{
+		Operator: model.Operator,
+		And:      convertInterfacesToTypeStrings(rules.And),
+		Or:       convertInterfacesToTypeStrings(rules.Or),
+		Groups:   model.Groups,
+	}, diags
 }
 
 func convertActionsToDataSource(actions []armis.Action) []PolicyDataSourceActionModel {
@@ -221,6 +453,56 @@ func convertMitreLabelsToDataSource(labels []armis.MitreAttackLabel) []PolicyDat
 	return result
 }
 
+// ConvertMitreLabelsToStrings converts the MITRE ATT&CK labels returned by the
+// API, which name their tactic and technique, to the
+// Matrix.TA####.T####[.###] format used by the policy resource. It fails when a
+// label cannot be found in the embedded ATT&CK catalog.
+func ConvertMitreLabelsToStrings(labels []armis.MitreAttackLabel) ([]string, error) {
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		resolved, err := mitre.Default().Resolve(label.Matrix, label.Tactic, label.Technique, label.SubTechnique)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resolved.String())
+	}
+
+	return result, nil
+}
+
+// ReconcileMitreLabels returns the mitre_attack_labels value to store after
+// reading labels from the API. prior is kept when it holds the same labels,
+// so that ordering and duplicates in configuration do not show as drift, and
+// when a label cannot be converted, so that labels missing from the catalog
+// do not cause a perpetual diff.
+func ReconcileMitreLabels(ctx context.Context, prior types.List, labels []armis.MitreAttackLabel) types.List {
+	converted, err := ConvertMitreLabelsToStrings(labels)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to convert MITRE ATT&CK labels, keeping labels from state", map[string]any{
+			"error": err.Error(),
+		})
+		return prior
+	}
+
+	if prior.IsUnknown() {
+		return ConvertStringSliceToList(converted)
+	}
+
+	priorLabels := ConvertListToStringSlice(prior)
+	if len(converted) == 0 && len(priorLabels) == 0 {
+		return prior
+	}
+
+	slices.Sort(priorLabels)
+	sorted := slices.Clone(converted)
+	slices.Sort(sorted)
+	if slices.Equal(slices.Compact(priorLabels), slices.Compact(sorted)) {
+		return prior
+	}
+
+	return ConvertStringSliceToList(converted)
+}
+
 func convertStringsToTypeStrings(values []string) []types.String {
 	if values == nil {
 		return nil
@@ -586,13 +868,14 @@ func ConvertActionsToList(actions []armis.Action) types.List {
 }
 
 // ResponseToPolicyFromGet converts armis.GetPolicySettings to PolicyResourceModel.
-func ResponseToPolicyFromGet(ctx context.Context, policy armis.GetPolicySettings) *PolicyResourceModel {
+func ResponseToPolicyFromGet(ctx context.Context, policy armis.GetPolicySettings) (*PolicyResourceModel, diag.Diagnostics) {
 	tflog.Debug(ctx, "Processing policy", map[string]any{
 		"policy_name":    policy.Name,
 		"policy_type":    policy.RuleType,
 		"policy_enabled": policy.IsEnabled,
 	})
 
+	rules, diags := ConvertRulesToModel(policy.Rules)
 	result := &PolicyResourceModel{
 		Name:        types.StringValue(policy.Name),
 		Description: types.StringValue(policy.Description),
@@ -600,23 +883,21 @@ func ResponseToPolicyFromGet(ctx context.Context, policy armis.GetPolicySettings
 		Labels:      ConvertStringSliceToList(policy.Labels),
 		RuleType:    types.StringValue(policy.RuleType),
 		Actions:     ConvertActionsToList(policy.Actions),
-		Rules: &RulesModel{
-			And: ConvertSliceToList(policy.Rules.And),
-			Or:  ConvertSliceToList(policy.Rules.Or),
-		},
+		Rules:       rules,
 	}
 
-	return result
+	return result, diags
 }
 
 // ResponseToPolicyFromUpdate converts armis.UpdatePolicySettings to PolicyResourceModel.
-func ResponseToPolicyFromUpdate(ctx context.Context, policy armis.UpdatePolicySettings) *PolicyResourceModel {
+func ResponseToPolicyFromUpdate(ctx context.Context, policy armis.UpdatePolicySettings) (*PolicyResourceModel, diag.Diagnostics) {
 	tflog.Debug(ctx, "Processing updated policy", map[string]any{
 		"policy_name":    policy.Name,
 		"policy_type":    policy.RuleType,
 		"policy_enabled": policy.IsEnabled,
 	})
 
+	rules, diags := ConvertRulesToModel(policy.Rules)
 	result := &PolicyResourceModel{
 		Name:        types.StringValue(policy.Name),
 		Description: types.StringValue(policy.Description),
@@ -624,13 +905,10 @@ func ResponseToPolicyFromUpdate(ctx context.Context, policy armis.UpdatePolicySe
 		Labels:      ConvertStringSliceToList(policy.Labels),
 		RuleType:    types.StringValue(policy.RuleType),
 		Actions:     ConvertActionsToList(policy.Actions),
-		Rules: &RulesModel{
-			And: ConvertSliceToList(policy.Rules.And),
-			Or:  ConvertSliceToList(policy.Rules.Or),
-		},
+		Rules:       rules,
 	}
 
-	return result
+	return result, diags
 }
 
 // BuildPolicySettings converts a PolicyResourceModel to armis.PolicySettings.
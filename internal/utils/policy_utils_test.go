@@ -5,9 +5,11 @@ package utils
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/1898andCo/armis-sdk-go/v2/armis"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/asqtypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -543,6 +545,27 @@ func TestConvertSliceToList(t *testing.T) {
 	}
 }
 
+// TestConvertSliceToRuleList tests the ConvertSliceToRuleList function.
+func TestConvertSliceToRuleList(t *testing.T) {
+	t.Parallel()
+
+	if result := ConvertSliceToRuleList(nil); !result.IsNull() {
+		t.Error("Expected null list")
+	}
+
+	result := ConvertSliceToRuleList([]any{"protocol:BMS", 123, "type:PLC"})
+	if !result.ElementType(context.Background()).Equal(asqtypes.QueryType{}) {
+		t.Errorf("Expected ASQ element type, got %s", result.ElementType(context.Background()))
+	}
+	elements := result.Elements()
+	if len(elements) != 2 {
+		t.Fatalf("Expected 2 elements (non-strings skipped), got %d", len(elements))
+	}
+	if !elements[0].Equal(asqtypes.NewQueryValue("protocol:BMS")) {
+		t.Errorf("Expected first rule 'protocol:BMS', got %s", elements[0])
+	}
+}
+
 // TestConvertMitreLabelsToDataSource tests the convertMitreLabelsToDataSource function.
 func TestConvertMitreLabelsToDataSource(t *testing.T) {
 	t.Parallel()
@@ -603,6 +626,95 @@ func TestConvertMitreLabelsToDataSource(t *testing.T) {
 	}
 }
 
+// TestConvertMitreLabelsToStrings tests the ConvertMitreLabelsToStrings function.
+func TestConvertMitreLabelsToStrings(t *testing.T) {
+	t.Parallel()
+
+	result, err := ConvertMitreLabelsToStrings([]armis.MitreAttackLabel{
+		{Matrix: "Enterprise", Tactic: "Initial Access", Technique: "Phishing", SubTechnique: "T1566.001"},
+		{Matrix: "Enterprise", Tactic: "Collection", Technique: "Input Capture", SubTechnique: "Keylogging"},
+		{Matrix: "ICS", Tactic: "Impact", Technique: "Loss of View"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"Enterprise.TA0001.T1566.001", "Enterprise.TA0009.T1056.001", "ICS.TA0105.T0829"}
+	if len(result) != len(want) {
+		t.Fatalf("Expected %d labels, got %d", len(want), len(result))
+	}
+	for i := range want {
+		if result[i] != want[i] {
+			t.Errorf("Expected label %d to be '%s', got '%s'", i, want[i], result[i])
+		}
+	}
+
+	if _, err := ConvertMitreLabelsToStrings([]armis.MitreAttackLabel{
+		{Matrix: "Mobile", Tactic: "Persistence", Technique: "Malware"},
+	}); err == nil {
+		t.Error("Expected an error for a technique missing from the catalog")
+	}
+}
+
+// TestReconcileMitreLabels tests the ReconcileMitreLabels function.
+func TestReconcileMitreLabels(t *testing.T) {
+	t.Parallel()
+
+	keylogging := armis.MitreAttackLabel{Matrix: "Enterprise", Tactic: "Collection", Technique: "Input Capture", SubTechnique: "Keylogging"}
+	phishing := armis.MitreAttackLabel{Matrix: "Enterprise", Tactic: "Initial Access", Technique: "Phishing"}
+	unknown := armis.MitreAttackLabel{Matrix: "Mobile", Tactic: "Persistence", Technique: "Malware"}
+
+	prior := ConvertStringSliceToList([]string{"Enterprise.TA0001.T1566", "Enterprise.TA0009.T1056.001"})
+
+	tests := []struct {
+		name   string
+		prior  types.List
+		labels []armis.MitreAttackLabel
+		want   types.List
+	}{
+		{
+			name:   "same labels in another order keep prior",
+			prior:  prior,
+			labels: []armis.MitreAttackLabel{keylogging, phishing},
+			want:   prior,
+		},
+		{
+			name:   "removed label shows as drift",
+			prior:  prior,
+			labels: []armis.MitreAttackLabel{keylogging},
+			want:   ConvertStringSliceToList([]string{"Enterprise.TA0009.T1056.001"}),
+		},
+		{
+			name:   "no labels keep null prior",
+			prior:  types.ListNull(types.StringType),
+			labels: nil,
+			want:   types.ListNull(types.StringType),
+		},
+		{
+			name:   "labels added outside Terraform show as drift",
+			prior:  types.ListNull(types.StringType),
+			labels: []armis.MitreAttackLabel{phishing},
+			want:   ConvertStringSliceToList([]string{"Enterprise.TA0001.T1566"}),
+		},
+		{
+			name:   "unknown label keeps prior",
+			prior:  prior,
+			labels: []armis.MitreAttackLabel{unknown},
+			want:   prior,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := ReconcileMitreLabels(context.Background(), tt.prior, tt.labels)
+			if !result.Equal(tt.want) {
+				t.Errorf("Expected %s, got %s", tt.want, result)
+			}
+		})
+	}
+}
+
 // TestConvertActionToDataSource tests the convertActionToDataSource function.
 func TestConvertActionToDataSource(t *testing.T) {
 	t.Parallel()
@@ -778,7 +890,10 @@ func TestBuildPolicyDataSourceModelFromGet(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := BuildPolicyDataSourceModelFromGet(tt.policy, tt.id)
+			result, diags := BuildPolicyDataSourceModelFromGet(tt.policy, tt.id)
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got %v", diags)
+			}
 			tt.validate(t, result)
 		})
 	}
@@ -854,7 +969,10 @@ func TestBuildPolicyDataSourceModelFromSingle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := BuildPolicyDataSourceModelFromSingle(tt.policy)
+			result, diags := BuildPolicyDataSourceModelFromSingle(tt.policy)
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got %v", diags)
+			}
 			tt.validate(t, result)
 		})
 	}
@@ -1852,6 +1970,27 @@ func TestConvertModelToRules(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "ASQ rules",
+			input: RulesModel{
+				And: types.ListValueMust(asqtypes.QueryType{}, []attr.Value{
+					asqtypes.NewQueryValue("protocol:BMS"),
+					asqtypes.NewQueryNull(),
+				}),
+				Or: types.ListNull(asqtypes.QueryType{}),
+			},
+			validate: func(t *testing.T, result armis.Rules, diags diag.Diagnostics) {
+				if len(result.And) != 1 {
+					t.Fatalf("Expected And length 1, got %d", len(result.And))
+				}
+				if result.And[0] != "protocol:BMS" {
+					t.Errorf("Expected And rule 'protocol:BMS', got '%v'", result.And[0])
+				}
+				if result.Or != nil {
+					t.Error("Expected Or to be nil")
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2371,7 +2510,10 @@ func TestResponseToPolicyFromGet(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := ResponseToPolicyFromGet(context.Background(), tt.input)
+			result, diags := ResponseToPolicyFromGet(context.Background(), tt.input)
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got %v", diags)
+			}
 			tt.validate(t, result)
 		})
 	}
@@ -2655,7 +2797,10 @@ func TestResponseToPolicyFromUpdate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := ResponseToPolicyFromUpdate(context.Background(), tt.input)
+			result, diags := ResponseToPolicyFromUpdate(context.Background(), tt.input)
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got %v", diags)
+			}
 			tt.validate(t, result)
 		})
 	}
@@ -2972,3 +3117,298 @@ func TestBuildPolicySettings(t *testing.T) {
 		})
 	}
 }
+
+// TestConvertModelToRulesGroups tests that rule groups are nested in the
+// rules selected by the operator.
+func TestConvertModelToRulesGroups(t *testing.T) {
+	t.Parallel()
+
+	groupType := RuleGroupObjectType(1)
+	newGroup := func(and, or []attr.Value) attr.Value {
+		attrs := map[string]attr.Value{
+			"operator": types.StringNull(),
+			"and":      types.ListNull(asqtypes.QueryType{}),
+			"or":       types.ListNull(asqtypes.QueryType{}),
+			"groups":   types.ListNull(RuleGroupObjectType(2)),
+		}
+		if and != nil {
+			attrs["and"] = types.ListValueMust(asqtypes.QueryType{}, and)
+		}
+		if or != nil {
+			attrs["or"] = types.ListValueMust(asqtypes.QueryType{}, or)
+		}
+		return types.ObjectValueMust(groupType.AttrTypes, attrs)
+	}
+	rules := types.ListValueMust(asqtypes.QueryType{}, []attr.Value{asqtypes.NewQueryValue("type:PLC")})
+	groups := types.ListValueMust(groupType, []attr.Value{
+		newGroup(nil, []attr.Value{asqtypes.NewQueryValue("protocol:BMS"), asqtypes.NewQueryValue("protocol:SMB")}),
+	})
+	group := map[string]any{"or": []any{"protocol:BMS", "protocol:SMB"}}
+
+	tests := []struct {
+		name    string
+		input   RulesModel
+		want    armis.Rules
+		wantErr bool
+	}{
+		{
+			name:  "groups join and rules",
+			input: RulesModel{Operator: types.StringValue("and"), And: rules, Or: types.ListNull(asqtypes.QueryType{}), Groups: groups},
+			want:  armis.Rules{And: []any{"type:PLC", group}},
+		},
+		{
+			name:  "groups join or rules",
+			input: RulesModel{Operator: types.StringValue("or"), And: types.ListNull(asqtypes.QueryType{}), Or: rules, Groups: groups},
+			want:  armis.Rules{Or: []any{"type:PLC", group}},
+		},
+		{
+			name:  "groups join the or rules next to and rules",
+			input: RulesModel{Operator: types.StringValue("or"), And: rules, Or: types.ListNull(asqtypes.QueryType{}), Groups: groups},
+			want:  armis.Rules{And: []any{"type:PLC"}, Or: []any{group}},
+		},
+		{
+			name: "group with and and or rules",
+			input: RulesModel{
+				Operator: types.StringValue("and"),
+				And:      types.ListNull(asqtypes.QueryType{}),
+				Or:       types.ListNull(asqtypes.QueryType{}),
+				Groups: types.ListValueMust(groupType, []attr.Value{
+					newGroup([]attr.Value{asqtypes.NewQueryValue("type:PLC")}, []attr.Value{asqtypes.NewQueryValue("protocol:BMS")}),
+				}),
+			},
+			want: armis.Rules{And: []any{map[string]any{"and": []any{"type:PLC"}, "or": []any{"protocol:BMS"}}}},
+		},
+		{
+			name:    "groups without operator",
+			input:   RulesModel{Operator: types.StringNull(), And: rules, Or: types.ListNull(asqtypes.QueryType{}), Groups: groups},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, diags := ConvertModelToRules(tt.input)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("Expected error %t, got %v", tt.wantErr, diags)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(result, tt.want) {
+				t.Errorf("Expected rules %#v, got %#v", tt.want, result)
+			}
+		})
+	}
+}
+
+// TestRuleGroupsRoundTrip tests that nested rule groups returned by the API
+// are sent back unchanged and shown by the policies data source.
+func TestRuleGroupsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		rules armis.Rules
+	}{
+		{
+			name: "A AND (B OR C)",
+			rules: armis.Rules{And: []any{
+				"type:PLC",
+				map[string]any{"or": []any{"protocol:BMS", "protocol:SMB"}},
+			}},
+		},
+		{
+			name: "(A AND B) OR (C AND (D OR E))",
+			rules: armis.Rules{Or: []any{
+				map[string]any{"and": []any{"type:PLC", "site:Plant"}},
+				map[string]any{"and": []any{
+					"type:HMI",
+					map[string]any{"or": []any{"protocol:BMS", "protocol:SMB"}},
+				}},
+			}},
+		},
+		{
+			// Rules are sent before groups, so the rules of each group are listed first.
+			name: "groups of groups",
+			rules: armis.Rules{And: []any{
+				map[string]any{"or": []any{
+					"type:RTU",
+					map[string]any{"and": []any{
+						"site:Plant",
+						map[string]any{"or": []any{"type:PLC", "type:HMI"}},
+					}},
+				}},
+			}},
+		},
+		{
+			name: "groups next to rules of the other operator",
+			rules: armis.Rules{
+				And: []any{"type:PLC"},
+				Or:  []any{map[string]any{"and": []any{"protocol:BMS", "site:Plant"}}},
+			},
+		},
+		{
+			name: "group with and and or rules",
+			rules: armis.Rules{And: []any{
+				"type:PLC",
+				map[string]any{
+					"and": []any{"site:Plant"},
+					"or":  []any{"protocol:BMS", map[string]any{"and": []any{"type:HMI", "site:Lab"}}},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model, diags := ResponseToPolicyFromGet(context.Background(), armis.GetPolicySettings{Rules: tt.rules})
+			if diags.HasError() {
+				t.Fatalf("Expected no errors reading rules, got %v", diags)
+			}
+			result, diags := ConvertModelToRules(*model.Rules)
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got %v", diags)
+			}
+			if !reflect.DeepEqual(result, tt.rules) {
+				t.Errorf("Expected rules %#v, got %#v", tt.rules, result)
+			}
+
+			dataSource, diags := BuildPolicyDataSourceModelFromGet(armis.GetPolicySettings{Rules: tt.rules}, "1")
+			if diags.HasError() {
+				t.Fatalf("Expected no errors building the data source model, got %v", diags)
+			}
+			if !dataSource.Rules.Operator.Equal(model.Rules.Operator) {
+				t.Errorf("Expected data source operator %s, got %s", model.Rules.Operator, dataSource.Rules.Operator)
+			}
+			if !dataSource.Rules.Groups.Equal(model.Rules.Groups) {
+				t.Errorf("Expected data source groups %s, got %s", model.Rules.Groups, dataSource.Rules.Groups)
+			}
+		})
+	}
+}
+
+// TestConvertRulesToModel tests the conversion of rules and rule groups
+// returned by the API.
+func TestConvertRulesToModel(t *testing.T) {
+	t.Parallel()
+
+	model, diags := ConvertRulesToModel(armis.Rules{And: []any{"type:PLC"}})
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+	if !model.Operator.IsNull() || !model.Groups.IsNull() {
+		t.Errorf("Expected null operator and groups without groups, got %s and %s", model.Operator, model.Groups)
+	}
+
+	// The API may echo an empty and list next to the or rules holding groups.
+	model, diags = ConvertRulesToModel(armis.Rules{
+		And: []any{},
+		Or:  []any{"type:PLC", map[string]any{"and": []any{}, "or": []any{"protocol:BMS", "protocol:SMB"}}},
+	})
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+	if !model.Operator.Equal(types.StringValue("or")) {
+		t.Errorf("Expected operator 'or', got %s", model.Operator)
+	}
+	group := model.Groups.Elements()[0].(types.Object).Attributes()
+	if !group["and"].IsNull() {
+		t.Errorf("Expected null and rules in a group echoing an empty list, got %s", group["and"])
+	}
+	if want := types.ListValueMust(asqtypes.QueryType{}, []attr.Value{
+		asqtypes.NewQueryValue("protocol:BMS"), asqtypes.NewQueryValue("protocol:SMB"),
+	}); !group["or"].Equal(want) {
+		t.Errorf("Expected or rules %s, got %s", want, group["or"])
+	}
+
+	// Groups nested as deep as the schema allows.
+	var nested any = "type:PLC"
+	for range MaxRuleGroupDepth {
+		nested = map[string]any{"and": []any{nested}}
+	}
+	if _, diags := ConvertRulesToModel(armis.Rules{And: []any{nested}}); diags.HasError() {
+		t.Errorf("Expected no errors for groups nested %d levels deep, got %v", MaxRuleGroupDepth, diags)
+	}
+
+	errorTests := []struct {
+		name  string
+		rules armis.Rules
+	}{
+		{
+			name:  "groups nested too deep",
+			rules: armis.Rules{Or: []any{map[string]any{"or": []any{nested}}}},
+		},
+		{
+			name: "groups in and and or rules",
+			rules: armis.Rules{
+				And: []any{map[string]any{"or": []any{"type:PLC"}}},
+				Or:  []any{map[string]any{"and": []any{"type:HMI"}}},
+			},
+		},
+		{
+			name: "nested groups in and and or rules",
+			rules: armis.Rules{And: []any{map[string]any{
+				"and": []any{map[string]any{"or": []any{"type:PLC"}}},
+				"or":  []any{map[string]any{"and": []any{"type:HMI"}}},
+			}}},
+		},
+		{
+			name:  "unsupported group operator",
+			rules: armis.Rules{And: []any{map[string]any{"not": []any{"type:PLC"}}}},
+		},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, diags := ConvertRulesToModel(tt.rules); !diags.HasError() {
+				t.Error("Expected an error")
+			}
+			if _, diags := BuildPolicyDataSourceModelFromGet(armis.GetPolicySettings{Rules: tt.rules}, "1"); !diags.HasError() {
+				t.Error("Expected an error building the data source model")
+			}
+		})
+	}
+}
+
+// TestPreserveRules tests that rules are kept from the prior model when the
+// API omits them or returns them empty.
+func TestPreserveRules(t *testing.T) {
+	t.Parallel()
+
+	null := types.ListNull(asqtypes.QueryType{})
+	empty := types.ListValueMust(asqtypes.QueryType{}, []attr.Value{})
+	plc := types.ListValueMust(asqtypes.QueryType{}, []attr.Value{asqtypes.NewQueryValue("type:PLC")})
+	hmi := types.ListValueMust(asqtypes.QueryType{}, []attr.Value{asqtypes.NewQueryValue("type:HMI")})
+
+	tests := []struct {
+		name  string
+		input []any
+		prior types.List
+		want  types.List
+	}{
+		{name: "omitted rules keep prior", input: nil, prior: plc, want: plc},
+		{name: "empty echo keeps null", input: []any{}, prior: null, want: null},
+		{name: "empty rules keep empty", input: []any{}, prior: empty, want: empty},
+		{name: "only groups keep empty", input: []any{map[string]any{"or": []any{"type:PLC"}}}, prior: empty, want: empty},
+		{name: "changed rules", input: []any{"type:HMI"}, prior: plc, want: hmi},
+		{name: "removed rules", input: []any{}, prior: plc, want: empty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, diags := ConvertRulesToModel(armis.Rules{And: tt.input})
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got %v", diags)
+			}
+			PreserveRules(result, &RulesModel{And: tt.prior, Or: null}, armis.Rules{And: tt.input})
+			if !result.And.Equal(tt.want) {
+				t.Errorf("Expected and rules %s, got %s", tt.want, result.And)
+			}
+		})
+	}
+}
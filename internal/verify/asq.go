@@ -0,0 +1,548 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ASQObjects are the objects known to be selectable with in:. Armis may
+// support more, so validators only warn about others.
+var ASQObjects = []string{
+	"activity",
+	"alerts",
+	"applications",
+	"connections",
+	"devices",
+	"riskFactors",
+	"users",
+	"vulnerabilities",
+}
+
+// ASQTimeFramePattern matches timeFrame values such as "7 Days" or "1 Day".
+var ASQTimeFramePattern = regexp.MustCompile(`^[1-9]\d* (Second|Minute|Hour|Day|Week|Month|Year)s?$`)
+
+// ASQExpr is a node of a parsed ASQ query.
+type ASQExpr interface {
+	isASQExpr()
+}
+
+// ASQAnd matches when every operand matches. Adjacent terms are joined by AND.
+type ASQAnd struct {
+	Operands []ASQExpr
+}
+
+// ASQOr matches when any operand matches.
+type ASQOr struct {
+	Operands []ASQExpr
+}
+
+// ASQNot matches when its operand does not, as in !type:PLC or NOT type:PLC.
+type ASQNot struct {
+	Operand ASQExpr
+}
+
+// ASQTerm compares a field, as in type:PLC,HMI, riskLevel:>5 or
+// device:(type:PLC).
+type ASQTerm struct {
+	Field string
+	// Operator is empty for equality, or one of >, >=, < and <=.
+	Operator string
+	Values   []ASQValue
+	// Query is the subquery of field:(...) terms, which have no values.
+	Query ASQExpr
+	// Pos is the 1-based position of the field in the query.
+	Pos int
+}
+
+// ASQValue is a value of a term, with quotes and escapes removed.
+type ASQValue struct {
+	Text   string
+	Quoted bool
+	// Pos is the 1-based position of the value in the query.
+	Pos int
+}
+
+func (*ASQAnd) isASQExpr()  {}
+func (*ASQOr) isASQExpr()   {}
+func (*ASQNot) isASQExpr()  {}
+func (*ASQTerm) isASQExpr() {}
+
+// ASQQuery is a parsed ASQ query. Expr is nil for an empty query.
+type ASQQuery struct {
+	Expr ASQExpr
+}
+
+// ASQError describes a syntax error in an ASQ query.
+type ASQError struct {
+	// Pos is the 1-based position of the offending token in the query.
+	Pos int
+	// Token is the offending token, or empty at the end of the query.
+	Token   string
+	Message string
+}
+
+func (e *ASQError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s at end of query (position %d)", e.Message, e.Pos)
+	}
+
+	return fmt.Sprintf("%s at position %d near %q", e.Message, e.Pos, e.Token)
+}
+
+// ParseASQ parses an Armis Standard Query. Terms are field comparisons such
+// as type:PLC, site:"Kansas City Plant", ipAddress:10.0.0.0/8,
+// riskLevel:>=5 or device:(type:PLC), combined with AND, OR, NOT or ! and
+// parentheses. Adjacent terms are joined by AND. The in: terms must name a
+// single object and the timeFrame: terms a duration ASQ accepts; the objects
+// themselves are checked by the validators.
+//
+// Errors are returned as *ASQError.
+func ParseASQ(query string) (*ASQQuery, error) {
+	p := &asqParser{input: []rune(query)}
+
+	p.skipSpace()
+	if p.atEnd() {
+		return &ASQQuery{}, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.atEnd() {
+		if p.peek() == ')' {
+			return nil, p.errorAt(p.pos, "unbalanced closing parenthesis")
+		}
+		return nil, p.errorAt(p.pos, "unexpected token")
+	}
+
+	return &ASQQuery{Expr: expr}, nil
+}
+
+// FirstTerm returns the leftmost term of the query, or nil for an empty
+// query.
+func (q *ASQQuery) FirstTerm() *ASQTerm {
+	expr := q.Expr
+	for expr != nil {
+		switch e := expr.(type) {
+		case *ASQAnd:
+			expr = e.Operands[0]
+		case *ASQOr:
+			expr = e.Operands[0]
+		case *ASQNot:
+			expr = e.Operand
+		case *ASQTerm:
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Terms returns every term of the query in order, including the terms of
+// subqueries.
+func (q *ASQQuery) Terms() []*ASQTerm {
+	var terms []*ASQTerm
+
+	var walk func(ASQExpr)
+	walk = func(expr ASQExpr) {
+		switch e := expr.(type) {
+		case *ASQAnd:
+			for _, operand := range e.Operands {
+				walk(operand)
+			}
+		case *ASQOr:
+			for _, operand := range e.Operands {
+				walk(operand)
+			}
+		case *ASQNot:
+			walk(e.Operand)
+		case *ASQTerm:
+			terms = append(terms, e)
+			if e.Query != nil {
+				walk(e.Query)
+			}
+		}
+	}
+	if q.Expr != nil {
+		walk(q.Expr)
+	}
+
+	return terms
+}
+
+// asqParser is a recursive descent parser over the runes of a query. Values
+// are scanned in the context of their term, since bare values such as MAC and
+// IPv6 addresses contain colons.
+type asqParser struct {
+	input []rune
+	pos   int
+}
+
+func (p *asqParser) atEnd() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *asqParser) peek() rune {
+	if p.atEnd() {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *asqParser) skipSpace() {
+	for !p.atEnd() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// errorAt returns an error for the token starting at index pos.
+func (p *asqParser) errorAt(pos int, format string, args ...any) *ASQError {
+	end := pos
+	for end < len(p.input) && !unicode.IsSpace(p.input[end]) && end-pos < 20 {
+		end++
+	}
+
+	return &ASQError{
+		Pos:     pos + 1,
+		Token:   string(p.input[pos:end]),
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// keyword consumes the boolean operator word when it comes next.
+func (p *asqParser) keyword(word string) bool {
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(string(p.input[p.pos:end]), word) {
+		return false
+	}
+	if end < len(p.input) {
+		next := p.input[end]
+		if !unicode.IsSpace(next) && next != '(' && next != '!' {
+			return false
+		}
+	}
+
+	p.pos = end
+	return true
+}
+
+// isKeyword reports whether the boolean operator word comes next without
+// consuming it.
+func (p *asqParser) isKeyword(word string) bool {
+	start := p.pos
+	found := p.keyword(word)
+	p.pos = start
+
+	return found
+}
+
+func (p *asqParser) parseOr() (ASQExpr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []ASQExpr{first}
+	for {
+		p.skipSpace()
+		start := p.pos
+		if !p.keyword("OR") {
+			break
+		}
+		p.skipSpace()
+		if p.atEnd() || p.peek() == ')' {
+			return nil, p.errorAt(start, "missing term after OR")
+		}
+
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	return &ASQOr{Operands: operands}, nil
+}
+
+func (p *asqParser) parseAnd() (ASQExpr, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []ASQExpr{first}
+	for {
+		p.skipSpace()
+		if p.atEnd() || p.peek() == ')' || p.isKeyword("OR") {
+			break
+		}
+
+		start := p.pos
+		if p.keyword("AND") {
+			p.skipSpace()
+			if p.atEnd() || p.peek() == ')' || p.isKeyword("OR") {
+				return nil, p.errorAt(start, "missing term after AND")
+			}
+		}
+
+		next, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	return &ASQAnd{Operands: operands}, nil
+}
+
+func (p *asqParser) parseUnary() (ASQExpr, error) {
+	p.skipSpace()
+
+	start := p.pos
+	if p.peek() == '!' || p.keyword("NOT") {
+		if p.peek() == '!' {
+			p.pos++
+		}
+		p.skipSpace()
+		if p.atEnd() || p.peek() == ')' {
+			return nil, p.errorAt(start, "missing term after negation")
+		}
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &ASQNot{Operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *asqParser) parsePrimary() (ASQExpr, error) {
+	p.skipSpace()
+
+	switch {
+	case p.atEnd():
+		return nil, p.errorAt(p.pos, "expected a field comparison such as type:PLC")
+	case p.peek() == ')':
+		return nil, p.errorAt(p.pos, "unbalanced closing parenthesis")
+	case p.peek() == '(':
+		open := p.pos
+		p.pos++
+		p.skipSpace()
+		if p.peek() == ')' {
+			return nil, p.errorAt(open, "empty parentheses")
+		}
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorAt(open, "missing closing parenthesis")
+		}
+		p.pos++
+
+		return expr, nil
+	}
+
+	return p.parseTerm()
+}
+
+func (p *asqParser) parseTerm() (ASQExpr, error) {
+	start := p.pos
+	for !p.atEnd() && isASQFieldRune(p.peek(), p.pos == start) {
+		p.pos++
+	}
+	field := string(p.input[start:p.pos])
+
+	if field == "" {
+		return nil, p.errorAt(start, "expected a field name")
+	}
+	if strings.EqualFold(field, "AND") || strings.EqualFold(field, "OR") {
+		return nil, p.errorAt(start, "unexpected %s", strings.ToUpper(field))
+	}
+	if p.peek() != ':' {
+		return nil, p.errorAt(start, "expected a field comparison such as %s:value", field)
+	}
+	p.pos++
+
+	term := &ASQTerm{Field: field, Pos: start + 1}
+
+	if p.atEnd() || unicode.IsSpace(p.peek()) {
+		return nil, p.errorAt(start, "missing value after %s:", field)
+	}
+
+	if p.peek() == '(' {
+		open := p.pos
+		p.pos++
+		p.skipSpace()
+		if p.peek() == ')' {
+			return nil, p.errorAt(open, "empty subquery")
+		}
+
+		query, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorAt(open, "missing closing parenthesis")
+		}
+		p.pos++
+
+		term.Query = query
+		return term, p.checkTerm(term)
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		end := p.pos + len(op)
+		if end <= len(p.input) && string(p.input[p.pos:end]) == op {
+			term.Operator = op
+			p.pos = end
+			break
+		}
+	}
+
+	for {
+		value, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+		term.Values = append(term.Values, value)
+
+		if p.peek() != ',' {
+			break
+		}
+		if term.Operator != "" {
+			return nil, p.errorAt(p.pos, "comparison with %s takes a single value", term.Operator)
+		}
+		p.pos++
+	}
+
+	return term, p.checkTerm(term)
+}
+
+// parseValue scans a quoted value or a bare value running up to whitespace,
+// a comma or a closing parenthesis.
+func (p *asqParser) parseValue(field string) (ASQValue, error) {
+	start := p.pos
+	if p.atEnd() || unicode.IsSpace(p.peek()) || p.peek() == ',' || p.peek() == ')' {
+		return ASQValue{}, p.errorAt(start, "missing value for %s", field)
+	}
+
+	if quote := p.peek(); quote == '"' || quote == '\'' {
+		p.pos++
+
+		var text strings.Builder
+		for {
+			if p.atEnd() {
+				return ASQValue{}, p.errorAt(start, "unterminated quoted value")
+			}
+
+			r := p.input[p.pos]
+			p.pos++
+			if r == quote {
+				break
+			}
+			if r == '\\' && !p.atEnd() {
+				r = p.input[p.pos]
+				p.pos++
+			}
+			text.WriteRune(r)
+		}
+
+		if !p.atEnd() && !unicode.IsSpace(p.peek()) && p.peek() != ',' && p.peek() != ')' {
+			return ASQValue{}, p.errorAt(p.pos, "unexpected character after quoted value")
+		}
+
+		return ASQValue{Text: text.String(), Quoted: true, Pos: start + 1}, nil
+	}
+
+	for !p.atEnd() {
+		r := p.peek()
+		if unicode.IsSpace(r) || r == ',' || r == ')' {
+			break
+		}
+		if r == '"' || r == '\'' || r == '(' {
+			return ASQValue{}, p.errorAt(p.pos, "unexpected %q in value, quote values containing it", r)
+		}
+		p.pos++
+	}
+
+	return ASQValue{Text: string(p.input[start:p.pos]), Pos: start + 1}, nil
+}
+
+// checkTerm validates the values of the in: and timeFrame: terms.
+func (p *asqParser) checkTerm(term *ASQTerm) error {
+	switch term.Field {
+	case "in":
+		if term.Query != nil || term.Operator != "" || len(term.Values) != 1 {
+			return p.errorAt(term.Pos-1, "in: takes a single object such as in:devices")
+		}
+	case "timeFrame":
+		if term.Query != nil || term.Operator != "" || len(term.Values) != 1 || !ASQTimeFramePattern.MatchString(term.Values[0].Text) {
+			pos := term.Pos - 1
+			if len(term.Values) > 0 {
+				pos = term.Values[0].Pos - 1
+			}
+			return p.errorAt(pos, `timeFrame takes a quoted duration such as "7 Days"`)
+		}
+	}
+
+	return nil
+}
+
+// asqObjectError returns an error for the object of an in: term of query
+// when it is not one of objects, or nil when it is.
+func asqObjectError(query string, object ASQValue, objects []string) *ASQError {
+	if slices.Contains(objects, object.Text) {
+		return nil
+	}
+
+	p := &asqParser{input: []rune(query)}
+	for _, known := range objects {
+		if strings.EqualFold(object.Text, known) {
+			return p.errorAt(object.Pos-1, "unknown object %q, did you mean %q", object.Text, known)
+		}
+	}
+	if len(objects) == 1 {
+		return p.errorAt(object.Pos-1, "query must select %s, for example in:%s", objects[0], objects[0])
+	}
+
+	return p.errorAt(object.Pos-1, "unknown object %q, expected one of %s", object.Text, strings.Join(objects, ", "))
+}
+
+// isASQFieldRune reports whether r can appear in a field name.
+func isASQFieldRune(r rune, first bool) bool {
+	if unicode.IsLetter(r) {
+		return true
+	}
+	if first {
+		return false
+	}
+
+	return unicode.IsDigit(r) || r == '_' || r == '.'
+}
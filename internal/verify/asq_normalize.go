@@ -0,0 +1,198 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// NormalizeASQ parses query and renders it in a canonical form, so that two
+// queries with the same meaning normalize to the same string. The canonical
+// form ignores whitespace, keyword case, quote style, the order of AND and OR
+// operands and the order of comma separated values, and removes duplicate
+// operands, duplicate values and double negations.
+//
+// Errors are returned as *ASQError.
+func NormalizeASQ(query string) (string, error) {
+	parsed, err := ParseASQ(query)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Expr == nil {
+		return "", nil
+	}
+
+	return formatASQ(normalizeASQExpr(parsed.Expr)), nil
+}
+
+// normalizeASQExpr flattens nested AND and OR expressions, removes double
+// negations, and sorts and deduplicates operands and values.
+func normalizeASQExpr(expr ASQExpr) ASQExpr {
+	switch e := expr.(type) {
+	case *ASQAnd:
+		operands := normalizeASQOperands(e.Operands, func(expr ASQExpr) []ASQExpr {
+			if and, ok := expr.(*ASQAnd); ok {
+				return and.Operands
+			}
+			return nil
+		})
+		if len(operands) == 1 {
+			return operands[0]
+		}
+
+		return &ASQAnd{Operands: operands}
+	case *ASQOr:
+		operands := normalizeASQOperands(e.Operands, func(expr ASQExpr) []ASQExpr {
+			if or, ok := expr.(*ASQOr); ok {
+				return or.Operands
+			}
+			return nil
+		})
+		if len(operands) == 1 {
+			return operands[0]
+		}
+
+		return &ASQOr{Operands: operands}
+	case *ASQNot:
+		operand := normalizeASQExpr(e.Operand)
+		if not, ok := operand.(*ASQNot); ok {
+			return not.Operand
+		}
+
+		return &ASQNot{Operand: operand}
+	case *ASQTerm:
+		term := &ASQTerm{Field: e.Field, Operator: e.Operator}
+		if e.Query != nil {
+			term.Query = normalizeASQExpr(e.Query)
+			return term
+		}
+
+		texts := make([]string, 0, len(e.Values))
+		for _, value := range e.Values {
+			texts = append(texts, value.Text)
+		}
+		slices.Sort(texts)
+		for _, text := range slices.Compact(texts) {
+			term.Values = append(term.Values, ASQValue{Text: text})
+		}
+
+		return term
+	}
+
+	return expr
+}
+
+// normalizeASQOperands normalizes operands, inlines the operands of nested
+// expressions of the same kind returned by nested, and returns them sorted
+// by their canonical form without duplicates. in: terms sort first so that
+// the canonical form of a query still starts with its object.
+func normalizeASQOperands(operands []ASQExpr, nested func(ASQExpr) []ASQExpr) []ASQExpr {
+	var flat []ASQExpr
+	for _, operand := range operands {
+		operand = normalizeASQExpr(operand)
+		if inner := nested(operand); inner != nil {
+			flat = append(flat, inner...)
+			continue
+		}
+		flat = append(flat, operand)
+	}
+
+	type keyed struct {
+		key  string
+		expr ASQExpr
+	}
+	sorted := make([]keyed, 0, len(flat))
+	for _, operand := range flat {
+		sorted = append(sorted, keyed{key: formatASQ(operand), expr: operand})
+	}
+	slices.SortFunc(sorted, func(a, b keyed) int {
+		aIn, bIn := strings.HasPrefix(a.key, "in:"), strings.HasPrefix(b.key, "in:")
+		if aIn != bIn {
+			if aIn {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.key, b.key)
+	})
+	sorted = slices.CompactFunc(sorted, func(a, b keyed) bool {
+		return a.key == b.key
+	})
+
+	result := make([]ASQExpr, 0, len(sorted))
+	for _, operand := range sorted {
+		result = append(result, operand.expr)
+	}
+
+	return result
+}
+
+// formatASQ renders expr with single spaces, implicit AND, upper case OR,
+// ! for negation and parentheses only where they are needed.
+func formatASQ(expr ASQExpr) string {
+	switch e := expr.(type) {
+	case *ASQAnd:
+		parts := make([]string, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			if _, ok := operand.(*ASQOr); ok {
+				parts = append(parts, "("+formatASQ(operand)+")")
+				continue
+			}
+			parts = append(parts, formatASQ(operand))
+		}
+
+		return strings.Join(parts, " ")
+	case *ASQOr:
+		parts := make([]string, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			parts = append(parts, formatASQ(operand))
+		}
+
+		return strings.Join(parts, " OR ")
+	case *ASQNot:
+		switch e.Operand.(type) {
+		case *ASQAnd, *ASQOr:
+			return "!(" + formatASQ(e.Operand) + ")"
+		}
+
+		return "!" + formatASQ(e.Operand)
+	case *ASQTerm:
+		if e.Query != nil {
+			return e.Field + ":(" + formatASQ(e.Query) + ")"
+		}
+
+		values := make([]string, 0, len(e.Values))
+		for _, value := range e.Values {
+			values = append(values, formatASQValue(value.Text, e.Operator == ""))
+		}
+
+		return e.Field + ":" + e.Operator + strings.Join(values, ",")
+	}
+
+	return ""
+}
+
+// formatASQValue renders a value bare when the parser reads it back
+// unchanged, and double quoted otherwise. Values of equality terms that
+// start with a comparison operator are quoted so they are not read as one.
+func formatASQValue(text string, equality bool) string {
+	bare := text != ""
+	if equality && (strings.HasPrefix(text, "<") || strings.HasPrefix(text, ">")) {
+		bare = false
+	}
+	for _, r := range text {
+		if unicode.IsSpace(r) || strings.ContainsRune(`,()"'`, r) {
+			bare = false
+			break
+		}
+	}
+	if bare {
+		return text
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify_test
+
+import (
+	"testing"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+)
+
+func TestNormalizeASQ(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"empty", "  ", ""},
+		{"whitespace", "  in:devices   type:PLC ", "in:devices type:PLC"},
+		{"quote style", `in:devices site:'Kansas City'`, `in:devices site:"Kansas City"`},
+		{"unneeded quotes", `in:devices riskLevel:"High"`, "in:devices riskLevel:High"},
+		{"escaped quote", `name:'Plant "A"'`, `name:"Plant \"A\""`},
+		{"value order", "type:PLC,HMI,PLC", "type:HMI,PLC"},
+		{"clause order", "site:Plant in:devices type:PLC", "in:devices site:Plant type:PLC"},
+		{"explicit and", "type:PLC AND site:Plant", "site:Plant type:PLC"},
+		{"keyword case", "type:PLC or not site:Plant", "!site:Plant OR type:PLC"},
+		{"not keyword", "NOT type:PLC", "!type:PLC"},
+		{"double negation", "!!type:PLC", "type:PLC"},
+		{"nested and", "type:PLC (site:A site:B)", "site:A site:B type:PLC"},
+		{"nested or", "(type:PLC OR type:HMI) OR type:RTU", "type:HMI OR type:PLC OR type:RTU"},
+		{"or inside and", "in:devices (type:PLC OR type:HMI)", "in:devices (type:HMI OR type:PLC)"},
+		{"negated group", "NOT (type:PLC OR type:HMI)", "!(type:HMI OR type:PLC)"},
+		{"redundant parentheses", "((type:PLC))", "type:PLC"},
+		{"duplicate operands", "type:PLC type:PLC", "type:PLC"},
+		{"comparison value", "in:devices riskLevel:>=5", "in:devices riskLevel:>=5"},
+		{"quoted comparison text", `name:">5"`, `name:">5"`},
+		{"subquery", "device:( site:B  type:PLC )", "device:(site:B type:PLC)"},
+		{"mac address", "macAddress:00:1a:2b:3c:4d:5e", "macAddress:00:1a:2b:3c:4d:5e"},
+		{"time frame", `in:alerts timeFrame:'7 Days'`, `in:alerts timeFrame:"7 Days"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := verify.NormalizeASQ(tt.query)
+			if err != nil {
+				t.Fatalf("NormalizeASQ(%q) returned error: %v", tt.query, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeASQ(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+
+			again, err := verify.NormalizeASQ(got)
+			if err != nil {
+				t.Fatalf("NormalizeASQ(%q) returned error: %v", got, err)
+			}
+			if again != got {
+				t.Errorf("NormalizeASQ is not idempotent: %q normalized to %q", got, again)
+			}
+		})
+	}
+}
+
+func TestNormalizeASQErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"type:", "(type:PLC", "in:devices,alerts"} {
+		if got, err := verify.NormalizeASQ(query); err == nil {
+			t.Errorf("NormalizeASQ(%q) = %q, want an error", query, got)
+		}
+	}
+}
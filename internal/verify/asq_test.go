@@ -0,0 +1,171 @@
+// Copyright (c) 1898 & Co.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
+)
+
+func TestParseASQ(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"empty", ""},
+		{"in clause", "in:devices"},
+		{"time frame", `in:devices timeFrame:"7 Days"`},
+		{"singular time frame", `in:devices timeFrame:"1 Day"`},
+		{"quoted value", `in:devices site:"Kansas City Plant"`},
+		{"single quoted value", `in:devices site:'Kansas City Plant'`},
+		{"escaped quote", `in:devices name:"Plant \"A\""`},
+		{"value list", "in:devices type:PLC,HMI"},
+		{"quoted value list", `in:devices site:"Plant A","Plant B"`},
+		{"cidr", "ipAddress:10.20.0.0/16"},
+		{"mac address", "macAddress:00:1a:2b:3c:4d:5e"},
+		{"ipv6 address", "ipAddress:2001:db8::1"},
+		{"comparison", "in:devices riskLevel:>=5"},
+		{"less than", "in:devices riskLevel:<3"},
+		{"explicit and", "type:PLC AND site:Plant"},
+		{"or", `in:devices riskLevel:"High" OR in:devices riskLevel:"Critical"`},
+		{"lowercase operators", "type:PLC and not site:Plant or type:HMI"},
+		{"bang negation", "!type:PLC"},
+		{"not keyword", "NOT type:PLC"},
+		{"parentheses", "in:devices (type:PLC OR type:HMI) site:Plant"},
+		{"nested parentheses", "((type:PLC OR (type:HMI !site:Lab)))"},
+		{"subquery", "in:activity device:(type:PLC site:Plant)"},
+		{"surrounding whitespace", "  in:devices   type:PLC  "},
+		{"dotted field", "in:devices device.type:PLC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := verify.ParseASQ(tt.query); err != nil {
+				t.Errorf("expected %q to parse, got: %s", tt.query, err)
+			}
+		})
+	}
+}
+
+func TestParseASQErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedPos   int
+		expectedToken string
+	}{
+		{"unterminated quote", `in:devices timeFrame:"7 Days`, 22, `"7`},
+		{"missing value", "in:devices type:", 12, "type:"},
+		{"space after colon", "in:devices type: PLC", 12, "type:"},
+		{"missing colon", "in:devices type PLC", 12, "type"},
+		{"empty list value", "in:devices type:PLC,,HMI", 21, ",HMI"},
+		{"trailing comma", "in:devices type:PLC,", 21, ""},
+		{"in with list", "in:devices,alerts", 1, "in:devices,alerts"},
+		{"unquoted time frame", "in:devices timeFrame:7", 22, "7"},
+		{"bad time frame unit", `in:devices timeFrame:"7 Fortnights"`, 22, `"7`},
+		{"unbalanced open", "in:devices (type:PLC OR type:HMI", 12, "(type:PLC"},
+		{"unbalanced close", "in:devices type:PLC)", 20, ")"},
+		{"empty parentheses", "in:devices ()", 12, "()"},
+		{"dangling or", "type:PLC OR", 10, "OR"},
+		{"dangling and", "type:PLC AND", 10, "AND"},
+		{"double operator", "type:PLC AND OR type:HMI", 10, "AND"},
+		{"leading or", "OR type:PLC", 1, "OR"},
+		{"dangling negation", "type:PLC !", 10, "!"},
+		{"comparison list", "riskLevel:>5,6", 13, ",6"},
+		{"text after quote", `site:"Plant"A`, 13, "A"},
+		{"quote inside value", `site:Plant"A"`, 11, `"A"`},
+		{"empty subquery", "device:()", 8, "()"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := verify.ParseASQ(tt.query)
+			if err == nil {
+				t.Fatalf("expected %q to fail", tt.query)
+			}
+
+			var asqErr *verify.ASQError
+			if !errors.As(err, &asqErr) {
+				t.Fatalf("expected *verify.ASQError, got %T", err)
+			}
+			if asqErr.Pos != tt.expectedPos {
+				t.Errorf("expected position %d, got %d (%s)", tt.expectedPos, asqErr.Pos, err)
+			}
+			if asqErr.Token != tt.expectedToken {
+				t.Errorf("expected token %q, got %q (%s)", tt.expectedToken, asqErr.Token, err)
+			}
+		})
+	}
+}
+
+func TestParseASQTree(t *testing.T) {
+	t.Parallel()
+
+	query, err := verify.ParseASQ(`in:devices (type:PLC,HMI OR !riskLevel:>=5) site:"Plant A"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &verify.ASQAnd{Operands: []verify.ASQExpr{
+		&verify.ASQTerm{Field: "in", Pos: 1, Values: []verify.ASQValue{{Text: "devices", Pos: 4}}},
+		&verify.ASQOr{Operands: []verify.ASQExpr{
+			&verify.ASQTerm{Field: "type", Pos: 13, Values: []verify.ASQValue{{Text: "PLC", Pos: 18}, {Text: "HMI", Pos: 22}}},
+			&verify.ASQNot{Operand: &verify.ASQTerm{Field: "riskLevel", Operator: ">=", Pos: 30, Values: []verify.ASQValue{{Text: "5", Pos: 42}}}},
+		}},
+		&verify.ASQTerm{Field: "site", Pos: 45, Values: []verify.ASQValue{{Text: "Plant A", Quoted: true, Pos: 50}}},
+	}}
+
+	if !reflect.DeepEqual(query.Expr, expected) {
+		t.Errorf("unexpected tree: %#v", query.Expr)
+	}
+
+	if first := query.FirstTerm(); first == nil || first.Field != "in" {
+		t.Errorf("expected the first term to be in:, got %#v", first)
+	}
+	if terms := query.Terms(); len(terms) != 4 {
+		t.Errorf("expected 4 terms, got %d", len(terms))
+	}
+}
+
+func TestASQTimeFramePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"1 Day", true},
+		{"7 Days", true},
+		{"1 Hour", true},
+		{"30 Seconds", true},
+		{"12 Months", true},
+		{"1 Year", true},
+		{"0 Days", false},
+		{"7 days", false},
+		{"7Days", false},
+		{"Days", false},
+		{"7 Fortnights", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			if got := verify.ASQTimeFramePattern.MatchString(tt.value); got != tt.expected {
+				t.Errorf("expected %q to match: %t, got %t", tt.value, tt.expected, got)
+			}
+		})
+	}
+}
@@ -5,16 +5,238 @@
 package verify
 
 import (
-	"regexp"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/1898andCo/terraform-provider-armis-centrix/internal/mitre"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
-// ValidMitreAttackLabel validates MITRE ATT&CK label format.
+// ValidMitreAttackLabel validates that a string is a MITRE ATT&CK label such
+// as Enterprise.TA0009.T1056.001 whose tactic, technique and sub-technique
+// are in the embedded ATT&CK catalog and belong together.
 func ValidMitreAttackLabel() validator.String {
-	return stringvalidator.RegexMatches(
-		regexp.MustCompile(`^(Enterprise|Mobile|ICS)\.TA\d{4}\.T\d{4}(\.\d{3})?$`),
-		"must be a valid MITRE ATT&CK label (e.g., Enterprise.TA0009.T1056.001)",
+	return mitreLabelValidator{}
+}
+
+type mitreLabelValidator struct{}
+
+func (v mitreLabelValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("must be a MITRE ATT&CK v%s label whose technique belongs to its tactic (e.g., Enterprise.TA0009.T1056.001)",
+		mitre.Default().Version)
+}
+
+func (v mitreLabelValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v mitreLabelValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	label, err := mitre.ParseLabel(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid MITRE ATT&CK Label",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, err.Error(), value),
+		)
+		return
+	}
+
+	catalog := mitre.Default()
+	if err := catalog.CheckLabel(label); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid MITRE ATT&CK Label",
+			fmt.Sprintf("Attribute %s value %q is not a valid MITRE ATT&CK v%s label: %s", req.Path, value, catalog.Version, err.Error()),
+		)
+	}
+}
+
+// ValidDuration validates that a string is a positive Go duration such as
+// "500ms", "30s" or "2m".
+func ValidDuration() validator.String {
+	return durationValidator{}
+}
+
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return `must be a positive duration (e.g., "500ms", "30s", "2m")`
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	d, err := time.ParseDuration(value)
+	if err == nil && d > 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Duration",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
 	)
 }
+
+// ValidASQ validates that a string is an ASQ query selecting its objects with
+// a leading in: term, such as 'in:devices timeFrame:"7 Days"'. When objects
+// are given, every in: term must select one of them. Otherwise objects
+// missing from ASQObjects are reported as a warning.
+func ValidASQ(objects ...string) validator.String {
+	return asqValidator{objects: objects}
+}
+
+// ValidASQRule validates that a string is an ASQ rule, such as the conditions
+// of a policy. Rules are queries without in: terms, since the objects they
+// match are selected elsewhere.
+func ValidASQRule() validator.String {
+	return asqValidator{rule: true}
+}
+
+type asqValidator struct {
+	rule    bool
+	objects []string
+}
+
+func (v asqValidator) Description(_ context.Context) string {
+	if v.rule {
+		return "must be a valid ASQ rule without in: terms (e.g., type:PLC)"
+	}
+	if len(v.objects) > 0 {
+		return fmt.Sprintf("must be a valid ASQ query starting with in:%s", strings.Join(v.objects, " or in:"))
+	}
+
+	return `must be a valid ASQ query starting with in:<object> (e.g., in:devices timeFrame:"7 Days")`
+}
+
+func (v asqValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v asqValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	parsed, err := v.check(value)
+	if err == nil {
+		v.warnUnknownObjects(value, parsed, req.Path, resp)
+		return
+	}
+
+	kind := "query"
+	if v.rule {
+		kind = "rule"
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid ASQ",
+		fmt.Sprintf("Attribute %s is not a valid ASQ %s: %s.\n\n%s", req.Path, kind, err.Error(), asqCaret(value, err.Pos)),
+	)
+}
+
+// check parses query and enforces where in: terms may appear and which
+// objects they select.
+func (v asqValidator) check(query string) (*ASQQuery, *ASQError) {
+	parsed, err := ParseASQ(query)
+	if err != nil {
+		var asqErr *ASQError
+		if errors.As(err, &asqErr) {
+			return nil, asqErr
+		}
+		return nil, &ASQError{Pos: 1, Message: err.Error()}
+	}
+
+	if v.rule {
+		if parsed.Expr == nil {
+			return nil, &ASQError{Pos: 1, Message: "rule is empty"}
+		}
+		for _, term := range parsed.Terms() {
+			if term.Field == "in" {
+				return nil, &ASQError{Pos: term.Pos, Token: "in:", Message: "rules must not contain in: terms"}
+			}
+		}
+		return parsed, nil
+	}
+
+	first := parsed.FirstTerm()
+	if first == nil {
+		return nil, &ASQError{Pos: 1, Message: "query is empty"}
+	}
+	if first.Field != "in" {
+		return nil, &ASQError{Pos: first.Pos, Token: first.Field + ":", Message: "query must start with in:<object>, for example in:devices"}
+	}
+
+	if len(v.objects) > 0 {
+		for _, term := range parsed.Terms() {
+			if term.Field != "in" {
+				continue
+			}
+			if err := asqObjectError(query, term.Values[0], v.objects); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return parsed, nil
+}
+
+// warnUnknownObjects warns about in: terms selecting objects missing from
+// ASQObjects, which Armis may still support, when any object is allowed.
+func (v asqValidator) warnUnknownObjects(query string, parsed *ASQQuery, attribute path.Path, resp *validator.StringResponse) {
+	if v.rule || len(v.objects) > 0 {
+		return
+	}
+
+	for _, term := range parsed.Terms() {
+		if term.Field != "in" {
+			continue
+		}
+		if err := asqObjectError(query, term.Values[0], ASQObjects); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				attribute,
+				"Unknown ASQ Object",
+				fmt.Sprintf("Attribute %s selects an object the provider does not know: %s. The query is sent to Armis as written.\n\n%s",
+					attribute, err.Error(), asqCaret(query, err.Pos)),
+			)
+		}
+	}
+}
+
+// asqCaret renders query on one line with a caret under the 1-based position
+// pos.
+func asqCaret(query string, pos int) string {
+	line := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, query)
+
+	column := pos - 1
+	if n := len([]rune(line)); column > n {
+		column = n
+	}
+
+	return "    " + line + "\n    " + strings.Repeat(" ", column) + "^"
+}
@@ -5,6 +5,7 @@ package verify_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/1898andCo/terraform-provider-armis-centrix/internal/verify"
@@ -23,15 +24,24 @@ func TestValidMitreAttackLabel(t *testing.T) {
 		// Valid formats - Enterprise matrix
 		{"valid enterprise with subtechnique", "Enterprise.TA0009.T1056.001", false},
 		{"valid enterprise without subtechnique", "Enterprise.TA0009.T1056", false},
-		{"valid enterprise different tactic", "Enterprise.TA0001.T1234", false},
+		{"valid enterprise different tactic", "Enterprise.TA0006.T1056.001", false},
+		{"valid enterprise initial access", "Enterprise.TA0001.T1566", false},
 
 		// Valid formats - Mobile matrix
-		{"valid mobile with subtechnique", "Mobile.TA0001.T1234.001", false},
-		{"valid mobile without subtechnique", "Mobile.TA0001.T1234", false},
+		{"valid mobile with subtechnique", "Mobile.TA0027.T1474.001", false},
+		{"valid mobile without subtechnique", "Mobile.TA0027.T1660", false},
 
 		// Valid formats - ICS matrix
-		{"valid ics with subtechnique", "ICS.TA0010.T0800.001", false},
-		{"valid ics without subtechnique", "ICS.TA0010.T0800", false},
+		{"valid ics without subtechnique", "ICS.TA0107.T0800", false},
+
+		// Invalid - not in the ATT&CK catalog
+		{"unknown tactic fails", "Enterprise.TA9999.T1056", true},
+		{"unknown technique fails", "Enterprise.TA0009.T0000", true},
+		{"technique of another tactic fails", "Enterprise.TA0040.T1056", true},
+		{"unknown subtechnique fails", "Enterprise.TA0009.T1056.009", true},
+		{"tactic of another matrix fails", "Mobile.TA0001.T1660", true},
+		{"technique of another matrix fails", "ICS.TA0100.T1056", true},
+		{"ics subtechnique fails", "ICS.TA0107.T0800.001", true},
 
 		// Invalid - wrong matrix/domain
 		{"lowercase enterprise fails", "enterprise.TA0009.T1056.001", true},
@@ -97,3 +107,197 @@ func TestValidMitreAttackLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestValidMitreAttackLabelSuggestions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"tactic of the technique", "Enterprise.TA0040.T1056.001", "did you mean Enterprise.TA0006.T1056.001 or Enterprise.TA0009.T1056.001?"},
+		{"unknown tactic", "Enterprise.TA9999.T1566", "did you mean Enterprise.TA0001.T1566?"},
+		{"other matrix", "Mobile.TA0001.T1660", "TA0001 is a tactic of the Enterprise matrix"},
+		{"close technique", "Enterprise.TA0009.T1065", "Enterprise.TA0009.T1005 (Data from Local System)"},
+		{"close subtechnique", "Enterprise.TA0009.T1056.005", "Enterprise.TA0009.T1056.001 (Keylogging)"},
+		{"no subtechniques", "ICS.TA0107.T0878.001", "has no sub-techniques, did you mean ICS.TA0107.T0878?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &validator.StringResponse{}
+			verify.ValidMitreAttackLabel().ValidateString(context.Background(), validator.StringRequest{
+				ConfigValue: types.StringValue(tt.value),
+			}, resp)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected error for value %q, but got none", tt.value)
+			}
+			if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.want) {
+				t.Errorf("expected error detail to contain %q, got: %s", tt.want, detail)
+			}
+		})
+	}
+}
+
+func TestValidDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.String
+		expectError bool
+	}{
+		{"seconds", types.StringValue("30s"), false},
+		{"milliseconds", types.StringValue("500ms"), false},
+		{"compound", types.StringValue("1m30s"), false},
+		{"null is skipped", types.StringNull(), false},
+		{"unknown is skipped", types.StringUnknown(), false},
+		{"zero fails", types.StringValue("0s"), true},
+		{"negative fails", types.StringValue("-5s"), true},
+		{"missing unit fails", types.StringValue("30"), true},
+		{"empty fails", types.StringValue(""), true},
+		{"garbage fails", types.StringValue("soon"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			verify.ValidDuration().ValidateString(context.Background(), req, resp)
+
+			if tt.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected error for value %s, but got none", tt.value)
+			}
+			if !tt.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("expected no error for value %s, but got: %s", tt.value, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestValidASQ(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		value          types.String
+		expectError    bool
+		expectedDetail string
+	}{
+		{"device query", types.StringValue(`in:devices timeFrame:"7 Days"`), false, ""},
+		{"or of queries", types.StringValue(`in:devices riskLevel:"High" OR in:devices riskLevel:"Critical"`), false, ""},
+		{"null is skipped", types.StringNull(), false, ""},
+		{"unknown is skipped", types.StringUnknown(), false, ""},
+		{"empty fails", types.StringValue(""), true, "query is empty"},
+		{"missing in fails", types.StringValue("type:PLC"), true, "must start with in:"},
+		{"syntax error fails", types.StringValue(`in:devices timeFrame:"7 Days`), true, `unterminated quoted value at position 22 near "\"7"`},
+		{"unknown object is accepted", types.StringValue("in:assets"), false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			verify.ValidASQ().ValidateString(context.Background(), req, resp)
+
+			if tt.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected error for value %s, but got none", tt.value)
+			}
+			if !tt.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("expected no error for value %s, but got: %s", tt.value, resp.Diagnostics.Errors())
+			}
+			if tt.expectedDetail != "" && resp.Diagnostics.HasError() && !strings.Contains(resp.Diagnostics[0].Detail(), tt.expectedDetail) {
+				t.Errorf("expected detail to contain %q, got: %s", tt.expectedDetail, resp.Diagnostics[0].Detail())
+			}
+		})
+	}
+}
+
+func TestValidASQObjects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		value          string
+		objects        []string
+		expectError    bool
+		expectWarning  bool
+		expectedDetail string
+	}{
+		{"allowed object", "in:devices type:PLC", []string{"devices"}, false, false, ""},
+		{"every in term is checked", "in:devices type:PLC OR in:alerts", []string{"devices"}, true, false, `query must select devices, for example in:devices at position 27 near "alerts"`},
+		{"other object fails", "in:vulnerabilities", []string{"devices"}, true, false, "query must select devices"},
+		{"wrong case suggests the object", "in:Devices", []string{"devices"}, true, false, `unknown object "Devices", did you mean "devices"`},
+		{"one of several objects", "in:alerts", []string{"devices", "alerts"}, false, false, ""},
+		{"known object", "in:connections", nil, false, false, ""},
+		{"unknown object warns", "in:device", nil, false, true, `unknown object "device", expected one of`},
+		{"wrong case warns with suggestion", "in:Devices", nil, false, true, `did you mean "devices"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{ConfigValue: types.StringValue(tt.value)}
+			resp := &validator.StringResponse{}
+
+			verify.ValidASQ(tt.objects...).ValidateString(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.expectError {
+				t.Errorf("expected error: %t, got: %s", tt.expectError, resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.expectWarning {
+				t.Errorf("expected warning: %t, got: %s", tt.expectWarning, resp.Diagnostics)
+			}
+			if tt.expectedDetail != "" && (len(resp.Diagnostics) == 0 || !strings.Contains(resp.Diagnostics[0].Detail(), tt.expectedDetail)) {
+				t.Errorf("expected detail to contain %q, got: %s", tt.expectedDetail, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestValidASQRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.String
+		expectError bool
+	}{
+		{"protocol", types.StringValue("protocol:BMS"), false},
+		{"cidr", types.StringValue("ipAddress:10.20.0.0/16"), false},
+		{"quoted site", types.StringValue(`site:"Kansas City Plant"`), false},
+		{"unknown is skipped", types.StringUnknown(), false},
+		{"in term fails", types.StringValue("in:devices type:PLC"), true},
+		{"empty fails", types.StringValue(""), true},
+		{"syntax error fails", types.StringValue("type PLC"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			verify.ValidASQRule().ValidateString(context.Background(), req, resp)
+
+			if tt.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected error for value %s, but got none", tt.value)
+			}
+			if !tt.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("expected no error for value %s, but got: %s", tt.value, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}